@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
@@ -25,10 +28,12 @@ func NewConvertHandler(registry converters.ConverterRegistry) *ConvertHandler {
 
 // ConvertRequest structure de la requête de conversion
 type ConvertRequest struct {
-	Type     string                 `json:"type" binding:"required"`
-	Content  string                 `json:"content" binding:"required"`
-	Options  map[string]interface{} `json:"options,omitempty"`
-	Filename string                 `json:"filename,omitempty"`
+	Type      string                  `json:"type" binding:"required"`
+	Content   string                  `json:"content" binding:"required_without=Documents"`
+	Options   map[string]interface{}  `json:"options,omitempty"`
+	Filename  string                  `json:"filename,omitempty"`
+	Documents []converters.SourceFile `json:"documents,omitempty"` // Fichiers d'override, fusionnés dans l'ordre
+	Bundle    []converters.SourceFile `json:"bundle,omitempty"`    // Fichiers référencés par extends/include
 }
 
 // ConvertResponse structure de la réponse de conversion
@@ -63,7 +68,14 @@ func (h *ConvertHandler) Convert(c *gin.Context) {
 		req.Type, len(req.Content), req.Options)
 
 	// Vérifier la taille du contenu
-	if len(req.Content) > 10*1024*1024 { // 10MB max
+	totalSize := len(req.Content)
+	for _, document := range req.Documents {
+		totalSize += len(document.Content)
+	}
+	for _, file := range req.Bundle {
+		totalSize += len(file.Content)
+	}
+	if totalSize > 10*1024*1024 { // 10MB max
 		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
 			"success": false,
 			"error":   "File too large",
@@ -85,10 +97,12 @@ func (h *ConvertHandler) Convert(c *gin.Context) {
 
 	// Créer la requête de conversion
 	conversionReq := converters.ConversionRequest{
-		Type:     req.Type,
-		Content:  req.Content,
-		Options:  req.Options,
-		Filename: req.Filename,
+		Type:      req.Type,
+		Content:   req.Content,
+		Options:   req.Options,
+		Filename:  req.Filename,
+		Documents: req.Documents,
+		Bundle:    req.Bundle,
 	}
 
 	// Effectuer la conversion
@@ -123,17 +137,21 @@ func (h *ConvertHandler) Convert(c *gin.Context) {
 	c.JSON(statusCode, response)
 }
 
-// ValidateRequest structure pour la validation
+// ValidateRequest structure pour la validation, avec les mêmes entrées que la conversion
 type ValidateRequest struct {
-	Type    string `json:"type" binding:"required"`
-	Content string `json:"content" binding:"required"`
+	Type      string                  `json:"type" binding:"required"`
+	Content   string                  `json:"content" binding:"required_without=Documents"`
+	Options   map[string]interface{}  `json:"options,omitempty"`
+	Filename  string                  `json:"filename,omitempty"`
+	Documents []converters.SourceFile `json:"documents,omitempty"` // Fichiers d'override, fusionnés dans l'ordre
+	Bundle    []converters.SourceFile `json:"bundle,omitempty"`    // Fichiers référencés par extends/include
 }
 
 // ValidateResponse structure de la réponse de validation
 type ValidateResponse struct {
-	Valid   bool     `json:"valid"`
-	Errors  []string `json:"errors,omitempty"`
-	Message string   `json:"message"`
+	Valid   bool                         `json:"valid"`
+	Errors  []converters.ConversionError `json:"errors,omitempty"`
+	Message string                       `json:"message"`
 }
 
 // Validate endpoint de validation des fichiers
@@ -144,7 +162,7 @@ func (h *ConvertHandler) Validate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"valid":   false,
 			"message": "Invalid request format",
-			"errors":  []string{err.Error()},
+			"errors":  []converters.ConversionError{{Code: "INVALID_REQUEST", Message: err.Error()}},
 		})
 		return
 	}
@@ -155,17 +173,35 @@ func (h *ConvertHandler) Validate(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"valid":   false,
 			"message": "Unsupported file type",
-			"errors":  []string{err.Error()},
+			"errors":  []converters.ConversionError{{Code: "UNSUPPORTED_TYPE", Message: err.Error()}},
 		})
 		return
 	}
 
-	// Valider le contenu
-	if err := converter.Validate(c.Request.Context(), req.Content, req.Type); err != nil {
+	// Valider le contenu ; le .env, les overrides et le bundle sont nécessaires à l'interpolation et aux inclusions
+	if validator, ok := converter.(converters.RequestValidator); ok {
+		err = validator.ValidateRequest(c.Request.Context(), converters.ConversionRequest{
+			Type:      req.Type,
+			Content:   req.Content,
+			Options:   req.Options,
+			Filename:  req.Filename,
+			Documents: req.Documents,
+			Bundle:    req.Bundle,
+		})
+	} else {
+		err = converter.Validate(c.Request.Context(), req.Content, req.Type)
+	}
+	if err != nil {
+		// Les convertisseurs retournent la liste structurée des violations ; sinon une seule erreur
+		var validationErrors converters.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			validationErrors = converters.ValidationErrors{{Code: "VALIDATION_ERROR", Message: err.Error()}}
+		}
+
 		c.JSON(http.StatusOK, ValidateResponse{
 			Valid:   false,
 			Message: "Validation failed",
-			Errors:  []string{err.Error()},
+			Errors:  validationErrors,
 		})
 		return
 	}
@@ -201,19 +237,32 @@ func NewUploadHandler(registry converters.ConverterRegistry) *UploadHandler {
 
 // UploadAndConvert upload et convertit un fichier
 func (h *UploadHandler) UploadAndConvert(c *gin.Context) {
-	// Récupérer le fichier uploadé
-	file, err := c.FormFile("file")
-	if err != nil {
+	// Récupérer les fichiers uploadés : le premier est le fichier de base,
+	// les suivants sont des overrides fusionnés dans l'ordre
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["file"]) == 0 {
+		details := "missing file part"
+		if err != nil {
+			details = err.Error()
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"error":   "No file uploaded",
-			"details": err.Error(),
+			"details": details,
 		})
 		return
 	}
 
-	// Vérifier la taille du fichier
-	if file.Size > 10*1024*1024 { // 10MB max
+	uploadedFiles := form.File["file"]
+	file := uploadedFiles[0]
+
+	// Vérifier la taille des fichiers
+	var totalSize int64
+	bundleFiles := form.File["bundle"]
+	for _, uploaded := range append(append([]*multipart.FileHeader(nil), uploadedFiles...), bundleFiles...) {
+		totalSize += uploaded.Size
+	}
+	if totalSize > 10*1024*1024 { // 10MB max
 		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
 			"success": false,
 			"error":   "File too large",
@@ -222,27 +271,53 @@ func (h *UploadHandler) UploadAndConvert(c *gin.Context) {
 		return
 	}
 
-	// Ouvrir le fichier
-	src, err := file.Open()
+	// Lire le contenu
+	content, err := readUploadedFile(file)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   "Failed to open uploaded file",
+			"error":   "Failed to read uploaded file",
 			"details": err.Error(),
 		})
 		return
 	}
-	defer src.Close()
 
-	// Lire le contenu
-	content := make([]byte, file.Size)
-	if _, err := src.Read(content); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to read uploaded file",
-			"details": err.Error(),
+	var documents []converters.SourceFile
+	for _, override := range uploadedFiles[1:] {
+		overrideContent, err := readUploadedFile(override)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to read uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+		documents = append(documents, converters.SourceFile{
+			Name:    override.Filename,
+			Content: overrideContent,
 		})
-		return
+	}
+
+	// Fichiers du bundle : le chemin relatif peut être fourni par "bundle_path",
+	// le nom de fichier multipart ne contenant pas de répertoire
+	bundlePaths := form.Value["bundle_path"]
+	var bundle []converters.SourceFile
+	for i, uploaded := range bundleFiles {
+		bundleContent, err := readUploadedFile(uploaded)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to read uploaded file",
+				"details": err.Error(),
+			})
+			return
+		}
+		name := uploaded.Filename
+		if i < len(bundlePaths) && bundlePaths[i] != "" {
+			name = bundlePaths[i]
+		}
+		bundle = append(bundle, converters.SourceFile{Name: name, Content: bundleContent})
 	}
 
 	// Déterminer le type de fichier
@@ -278,6 +353,22 @@ func (h *UploadHandler) UploadAndConvert(c *gin.Context) {
 		}
 	}
 
+	// Fichier .env optionnel utilisé pour l'interpolation des variables
+	if envFile, err := c.FormFile("env_file"); err == nil {
+		envContent, err := readUploadedFile(envFile)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Failed to read env file",
+				"details": err.Error(),
+			})
+			return
+		}
+		options["envFile"] = envContent
+	} else if envContent := c.PostForm("envFile"); envContent != "" {
+		options["envFile"] = envContent
+	}
+
 	// Obtenir le convertisseur
 	converter, err := h.registry.GetConverter(fileType)
 	if err != nil {
@@ -291,10 +382,12 @@ func (h *UploadHandler) UploadAndConvert(c *gin.Context) {
 
 	// Créer la requête de conversion
 	conversionReq := converters.ConversionRequest{
-		Type:     fileType,
-		Content:  string(content),
-		Options:  options,
-		Filename: file.Filename,
+		Type:      fileType,
+		Content:   content,
+		Options:   options,
+		Filename:  file.Filename,
+		Documents: documents,
+		Bundle:    bundle,
 	}
 
 	// Effectuer la conversion
@@ -327,6 +420,13 @@ func (h *UploadHandler) UploadAndConvert(c *gin.Context) {
 		"size":     file.Size,
 		"type":     fileType,
 	}
+	if len(documents) > 0 {
+		overrides := make([]string, len(documents))
+		for i, document := range documents {
+			overrides[i] = document.Name
+		}
+		response.Metadata["override_files"] = overrides
+	}
 
 	statusCode := http.StatusOK
 	if !result.Success {
@@ -357,3 +457,23 @@ func determineFileType(filename string) string {
 
 	return ""
 }
+
+// readUploadedFile lit le contenu complet d'un fichier uploadé
+func readUploadedFile(file *multipart.FileHeader) (string, error) {
+	if file.Size > 10*1024*1024 { // 10MB max
+		return "", fmt.Errorf("file %s is too large (maximum file size is 10MB)", file.Filename)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
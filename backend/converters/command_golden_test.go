@@ -0,0 +1,75 @@
+package converters
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"devops-converter/converters/kubernetes"
+)
+
+var updateGolden = flag.Bool("update", false, "regenerate the golden files in testdata")
+
+// commandGolden commande du conteneur généré et avertissements associés
+type commandGolden struct {
+	Command  []string `yaml:"command,omitempty"`
+	Args     []string `yaml:"args,omitempty"`
+	Warnings []string `yaml:"warnings,omitempty"`
+}
+
+// TestContainerCommandGolden vérifie la traduction entrypoint/command sur les cas de référence Docker
+// (testdata/commands/<cas>.yaml -> <cas>.golden). Régénérer avec : go test ./converters -run Golden -update
+func TestContainerCommandGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "commands", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	converter := NewDockerComposeToKubernetesConverter()
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			result, err := converter.Convert(context.Background(), ConversionRequest{
+				Type:    "docker-compose",
+				Content: string(content),
+				Options: map[string]interface{}{"allInOne": false},
+			})
+			require.NoError(t, err)
+			require.Empty(t, result.Errors)
+
+			var deployment kubernetes.Deployment
+			for _, file := range result.Files {
+				if file.Path == "deployments/app-deployment.yaml" {
+					require.NoError(t, yaml.Unmarshal([]byte(file.Content), &deployment))
+				}
+			}
+			require.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+
+			container := deployment.Spec.Template.Spec.Containers[0]
+			got := commandGolden{Command: container.Command, Args: container.Args}
+			for _, warning := range result.Warnings {
+				got.Warnings = append(got.Warnings, warning.Code)
+			}
+			actual, err := yaml.Marshal(got)
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", "commands", name+".golden")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, actual, 0o644))
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package converters
+
+import (
+	"sort"
+	"strings"
+
+	"devops-converter/converters/docker"
+	"devops-converter/converters/kubernetes"
+)
+
+// Statuts de couverture d'une clé docker-compose
+const (
+	CoverageMapped  = "mapped"
+	CoveragePartial = "partial"
+	CoverageIgnored = "ignored"
+)
+
+// FieldCoverage décrit le traitement d'une clé d'un service
+type FieldCoverage struct {
+	Key    string `json:"key"`   // Clé docker-compose, ex: deploy.replicas
+	Field  string `json:"field"` // Pointeur JSON, ex: /services/web/deploy/replicas
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+}
+
+// ServiceCoverage regroupe la couverture des clés d'un service
+type ServiceCoverage struct {
+	Service string          `json:"service"`
+	Fields  []FieldCoverage `json:"fields"`
+	Mapped  int             `json:"mapped"`
+	Partial int             `json:"partial"`
+	Ignored int             `json:"ignored"`
+}
+
+// CoverageReport rapport de couverture de la conversion, par service et par clé
+type CoverageReport struct {
+	Services []ServiceCoverage `json:"services"`
+	Mapped   int               `json:"mapped"`
+	Partial  int               `json:"partial"`
+	Ignored  int               `json:"ignored"`
+}
+
+// coverageRule statut par défaut d'une clé docker-compose
+type coverageRule struct {
+	status string
+	reason string
+}
+
+// Clés dont les sous-clés sont détaillées dans le rapport
+var detailedCoverageKeys = map[string]bool{
+	"deploy":      true,
+	"healthcheck": true,
+}
+
+// coverageRules statut de chaque clé de service connue du convertisseur
+var coverageRules = map[string]coverageRule{
+	"image":       {CoverageMapped, ""},
+	"ports":       {CoverageMapped, ""},
+	"environment": {CoverageMapped, ""},
+	"volumes":     {CoverageMapped, ""},
+	"working_dir": {CoverageMapped, ""},
+	"privileged":  {CoverageMapped, ""},
+	"read_only":   {CoverageMapped, ""},
+	"user":        {CoverageMapped, ""},
+	"profiles":    {CoverageMapped, "applied during service selection"},
+	"secrets":     {CoverageMapped, ""},
+	"configs":     {CoverageMapped, ""},
+	"command":     {CoverageMapped, ""},
+	"entrypoint":  {CoverageMapped, ""},
+
+	"healthcheck.test":           {CoverageMapped, ""},
+	"healthcheck.interval":       {CoverageMapped, ""},
+	"healthcheck.timeout":        {CoverageMapped, ""},
+	"healthcheck.retries":        {CoverageMapped, ""},
+	"healthcheck.start_period":   {CoverageMapped, ""},
+	"healthcheck.start_interval": {CoverageMapped, ""},
+	"healthcheck.disable":        {CoverageMapped, ""},
+
+	"deploy.resources":      {CoveragePartial, "only cpus and memory limits and reservations are mapped"},
+	"deploy.replicas":       {CoverageMapped, ""},
+	"deploy.mode":           {CoverageMapped, ""},
+	"deploy.update_config":  {CoveragePartial, "only parallelism, delay and order are mapped, and only for DaemonSets"},
+	"deploy.restart_policy": {CoveragePartial, "only condition and max_attempts are mapped, and only for Jobs and CronJobs"},
+
+	"build":          {CoverageIgnored, "images are not built; set image to a pushed image"},
+	"container_name": {CoverageIgnored, "pod names are generated by the Deployment"},
+	"restart":        {CoverageMapped, ""},
+	"labels":         {CoveragePartial, "only the kompose.controller.type, kompose.cronjob.*, devops-converter.ingress.* and Traefik router labels are interpreted; labels are not copied to Kubernetes metadata"},
+	"networks":       {CoveragePartial, "aliases become extra Services; networks are isolated with NetworkPolicies only when the networkPolicies option is set; static addresses are not mapped"},
+	"links":          {CoverageMapped, ""},
+	"depends_on":     {CoverageMapped, ""},
+	"expose":         {CoverageIgnored, "only ports are exposed through the Service"},
+	"env_file":       {CoverageIgnored, "env_file contents are not read; declare the variables in environment"},
+	"tmpfs":          {CoverageIgnored, "use a tmpfs volume mount instead"},
+	"ulimits":        {CoverageIgnored, "Kubernetes has no per-container ulimits"},
+	"logging":        {CoverageIgnored, "logging is configured at the cluster level"},
+	"stdin_open":     {CoverageIgnored, "interactive settings are not forwarded"},
+	"tty":            {CoverageIgnored, "interactive settings are not forwarded"},
+	"pid":            {CoverageIgnored, "host PID namespace sharing is not mapped"},
+	"ipc":            {CoverageIgnored, "host IPC namespace sharing is not mapped"},
+	"shm_size":       {CoverageIgnored, "mount a memory-backed emptyDir on /dev/shm instead"},
+	"cap_add":        {CoverageMapped, ""},
+	"cap_drop":       {CoverageMapped, ""},
+	"security_opt":   {CoverageMapped, ""},
+	"sysctls":        {CoverageMapped, ""},
+	"group_add":      {CoverageMapped, ""},
+	"userns_mode":    {CoverageMapped, ""},
+	"extra_hosts":    {CoverageIgnored, "extra hosts are not mapped to hostAliases"},
+	"dns":            {CoverageIgnored, "DNS settings are not mapped to dnsConfig"},
+	"dns_search":     {CoverageIgnored, "DNS settings are not mapped to dnsConfig"},
+	"dns_opt":        {CoverageIgnored, "DNS settings are not mapped to dnsConfig"},
+	"devices":        {CoverageIgnored, "host devices require a Kubernetes device plugin"},
+}
+
+// buildCoverageReport construit le rapport de couverture à partir des clés présentes dans les fichiers source
+func buildCoverageReport(compose *docker.DockerCompose) CoverageReport {
+	report := CoverageReport{Services: []ServiceCoverage{}}
+
+	names := make([]string, 0, len(compose.Services))
+	for name := range compose.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		service := compose.Services[name]
+		coverage := ServiceCoverage{Service: name, Fields: []FieldCoverage{}}
+
+		for _, key := range serviceSourceKeys(compose.Positions, name) {
+			status, reason := fieldCoverage(key, service)
+			field := docker.ServiceField(name, strings.Split(key, ".")...)
+			position := compose.Positions[field]
+
+			coverage.Fields = append(coverage.Fields, FieldCoverage{
+				Key:    key,
+				Field:  field,
+				Status: status,
+				Reason: reason,
+				File:   position.File,
+				Line:   position.Line,
+			})
+
+			switch status {
+			case CoverageMapped:
+				coverage.Mapped++
+			case CoveragePartial:
+				coverage.Partial++
+			default:
+				coverage.Ignored++
+			}
+		}
+
+		report.Mapped += coverage.Mapped
+		report.Partial += coverage.Partial
+		report.Ignored += coverage.Ignored
+		report.Services = append(report.Services, coverage)
+	}
+
+	return report
+}
+
+// serviceSourceKeys retourne les clés d'un service présentes dans les fichiers source,
+// dans l'ordre du fichier ; deploy et healthcheck sont détaillés par sous-clé
+func serviceSourceKeys(positions docker.SourceMap, service string) []string {
+	prefix := docker.ServiceField(service) + "/"
+
+	var keys []string
+	for pointer := range positions {
+		if !strings.HasPrefix(pointer, prefix) {
+			continue
+		}
+		segments := strings.Split(strings.TrimPrefix(pointer, prefix), "/")
+		switch {
+		case len(segments) == 1 && !detailedCoverageKeys[segments[0]]:
+			keys = append(keys, segments[0])
+		case len(segments) == 2 && detailedCoverageKeys[segments[0]]:
+			keys = append(keys, segments[0]+"."+segments[1])
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		left := positions[docker.ServiceField(service, strings.Split(keys[i], ".")...)]
+		right := positions[docker.ServiceField(service, strings.Split(keys[j], ".")...)]
+		if left.File != right.File {
+			return left.File < right.File
+		}
+		if left.Line != right.Line {
+			return left.Line < right.Line
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// fieldCoverage retourne le statut d'une clé, affiné selon la valeur du service
+func fieldCoverage(key string, service docker.Service) (string, string) {
+	switch key {
+	case "volumes":
+		for _, volume := range service.Volumes {
+			if volume.Type == docker.VolumeTypeNpipe {
+				return CoveragePartial, "named pipe mounts are skipped"
+			}
+			if volume.Type == docker.VolumeTypeBind && !strings.HasPrefix(volume.Source, "/") {
+				return CoveragePartial, "relative bind mounts cannot be used as hostPath volumes"
+			}
+		}
+	case "secrets", "configs":
+		references := service.Secrets
+		if key == "configs" {
+			references = service.Configs
+		}
+		for _, reference := range references {
+			if reference.UID != "" || reference.GID != "" {
+				return CoveragePartial, "uid and gid cannot be set on mounted files"
+			}
+		}
+	case "entrypoint":
+		if isCommandReset(service.Entrypoint) && !hasCommandArgs(service.Command) {
+			return CoveragePartial, "an empty entrypoint without command cannot be expressed; the image defaults run"
+		}
+	case "command":
+		if isCommandReset(service.Command) && service.Entrypoint == nil {
+			return CoveragePartial, "the image command cannot be cleared without an entrypoint"
+		}
+	case "user", "cap_add", "cap_drop", "security_opt", "sysctls", "group_add", "userns_mode":
+		_, _, issues := kubernetes.ResolveSecurity(securityToMap(service))
+		for _, issue := range issues {
+			if issue.Path[0] == key {
+				return CoveragePartial, issue.Reason
+			}
+		}
+	}
+
+	if rule, ok := coverageRules[key]; ok {
+		return rule.status, rule.reason
+	}
+
+	if strings.HasPrefix(key, "x-") {
+		return CoverageIgnored, "extension fields are not interpreted"
+	}
+	if strings.HasPrefix(key, "deploy.") {
+		return CoverageIgnored, "Swarm deployment settings have no Kubernetes equivalent"
+	}
+	return CoverageIgnored, "not supported by the converter"
+}
@@ -0,0 +1,52 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"devops-converter/converters/docker"
+)
+
+func TestBuildCoverageReport(t *testing.T) {
+	compose, err := docker.ParseDockerCompose(`services:
+  web:
+    image: nginx
+    user: www-data
+    restart: always
+    cap_add: [NET_ADMIN]
+    x-custom: true
+    deploy:
+      replicas: 3
+      resources:
+        limits:
+          memory: 512M
+`)
+	require.NoError(t, err)
+
+	report := buildCoverageReport(compose)
+	require.Len(t, report.Services, 1)
+
+	statuses := make(map[string]string)
+	for _, field := range report.Services[0].Fields {
+		statuses[field.Key] = field.Status
+		assert.NotZero(t, field.Line, field.Key)
+		if field.Status != CoverageMapped {
+			assert.NotEmpty(t, field.Reason, field.Key)
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"image":            CoverageMapped,
+		"user":             CoveragePartial,
+		"restart":          CoverageMapped,
+		"cap_add":          CoverageMapped,
+		"x-custom":         CoverageIgnored,
+		"deploy.replicas":  CoverageMapped,
+		"deploy.resources": CoveragePartial,
+	}, statuses)
+	assert.Equal(t, 4, report.Mapped)
+	assert.Equal(t, 2, report.Partial)
+	assert.Equal(t, 1, report.Ignored)
+}
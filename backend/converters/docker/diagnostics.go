@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic représente une erreur ou un avertissement localisé dans un fichier docker-compose
+type Diagnostic struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	File       string `json:"file,omitempty"`
+	Service    string `json:"service,omitempty"`
+	Field      string `json:"field,omitempty"` // Pointeur JSON, ex: /services/web/ports/0
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Error implémente l'interface error
+func (d Diagnostic) Error() string {
+	switch {
+	case d.File != "" && d.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	case d.Line > 0:
+		return fmt.Sprintf("line %d: %s", d.Line, d.Message)
+	default:
+		return d.Message
+	}
+}
+
+// ParseErrors regroupe toutes les erreurs détectées pendant le parsing
+type ParseErrors []Diagnostic
+
+// Error implémente l'interface error
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, d := range e {
+		messages[i] = d.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// jsonPointer construit un pointeur JSON (RFC 6901) à partir d'un chemin
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	escaped := make([]string, len(path))
+	for i, segment := range path {
+		segment = strings.ReplaceAll(segment, "~", "~0")
+		escaped[i] = strings.ReplaceAll(segment, "/", "~1")
+	}
+
+	return "/" + strings.Join(escaped, "/")
+}
+
+// serviceFromPath retourne le nom du service concerné par un chemin, s'il y en a un
+func serviceFromPath(path []string) string {
+	if len(path) >= 2 && path[0] == "services" {
+		return path[1]
+	}
+	return ""
+}
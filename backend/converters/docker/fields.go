@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StringList accepte une chaîne ou une liste de chaînes (dns, dns_search, include.path...)
+type StringList []string
+
+// UnmarshalYAML implémente yaml.Unmarshaler
+func (s *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = []string{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// LabelMap représente des labels en syntaxe map (clé: valeur) ou liste ("clé=valeur")
+type LabelMap map[string]string
+
+// UnmarshalYAML implémente yaml.Unmarshaler
+func (m *LabelMap) UnmarshalYAML(value *yaml.Node) error {
+	labels := make(LabelMap)
+
+	if value.Kind == yaml.SequenceNode {
+		for _, item := range value.Content {
+			key, label, _ := strings.Cut(item.Value, "=")
+			if key == "" {
+				return fmt.Errorf("line %d: invalid label %q, expected KEY=VALUE", item.Line, item.Value)
+			}
+			labels[key] = label
+		}
+		*m = labels
+		return nil
+	}
+
+	var mapping map[string]string
+	if err := value.Decode(&mapping); err != nil {
+		return err
+	}
+	for key, label := range mapping {
+		labels[key] = label
+	}
+	*m = labels
+	return nil
+}
+
+// ExtraHost représente une entrée extra_hosts (nom d'hôte -> adresse IP)
+type ExtraHost struct {
+	Host string
+	IP   string
+}
+
+// ExtraHostList représente les entrées extra_hosts d'un service
+type ExtraHostList []ExtraHost
+
+// UnmarshalYAML décode les syntaxes liste ("host:ip", "host=ip") et map (host: ip ou host: [ip...])
+func (l *ExtraHostList) UnmarshalYAML(value *yaml.Node) error {
+	var hosts ExtraHostList
+
+	switch value.Kind {
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			host, ip, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				host, ip, ok = strings.Cut(item.Value, ":")
+			}
+			if !ok || host == "" || ip == "" {
+				return fmt.Errorf("line %d: invalid extra_hosts entry %q, expected HOST:IP", item.Line, item.Value)
+			}
+			hosts = append(hosts, ExtraHost{Host: host, IP: strings.Trim(ip, "[]")})
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			host := value.Content[i].Value
+			var ips StringList
+			if err := value.Content[i+1].Decode(&ips); err != nil {
+				return err
+			}
+			for _, ip := range ips {
+				hosts = append(hosts, ExtraHost{Host: host, IP: strings.Trim(ip, "[]")})
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: extra_hosts must be a list or a mapping", value.Line)
+	}
+
+	*l = hosts
+	return nil
+}
+
+// ByIP regroupe les noms d'hôtes par adresse IP, dans l'ordre des adresses
+func (l ExtraHostList) ByIP() ([]string, map[string][]string) {
+	hostnames := make(map[string][]string)
+	var ips []string
+	for _, entry := range l {
+		if _, ok := hostnames[entry.IP]; !ok {
+			ips = append(ips, entry.IP)
+		}
+		hostnames[entry.IP] = append(hostnames[entry.IP], entry.Host)
+	}
+	sort.Strings(ips)
+	return ips, hostnames
+}
+
+// DeviceMapping représente un périphérique de l'hôte exposé au conteneur
+type DeviceMapping struct {
+	Source      string `yaml:"source"`
+	Target      string `yaml:"target,omitempty"`
+	Permissions string `yaml:"permissions,omitempty"`
+}
+
+// DeviceList représente les périphériques d'un service (syntaxe courte ou longue)
+type DeviceList []DeviceMapping
+
+// UnmarshalYAML décode "/dev/ttyUSB0[:/dev/ttyUSB0[:rwm]]" ou {source, target, permissions}
+func (l *DeviceList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: devices must be a list", value.Line)
+	}
+
+	var devices DeviceList
+	for _, item := range value.Content {
+		var device DeviceMapping
+		switch item.Kind {
+		case yaml.ScalarNode:
+			parts := strings.Split(item.Value, ":")
+			if len(parts) > 3 || parts[0] == "" {
+				return fmt.Errorf("line %d: invalid device %q", item.Line, item.Value)
+			}
+			device.Source = parts[0]
+			if len(parts) > 1 {
+				device.Target = parts[1]
+			}
+			if len(parts) > 2 {
+				device.Permissions = parts[2]
+			}
+		case yaml.MappingNode:
+			if err := item.Decode(&device); err != nil {
+				return err
+			}
+			if device.Source == "" {
+				return fmt.Errorf("line %d: device source is required", item.Line)
+			}
+		default:
+			return fmt.Errorf("line %d: invalid device definition", item.Line)
+		}
+		if device.Target == "" {
+			device.Target = device.Source
+		}
+		devices = append(devices, device)
+	}
+
+	*l = devices
+	return nil
+}
@@ -0,0 +1,300 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseEnvFile parse le contenu d'un fichier .env en variables d'environnement
+func ParseEnvFile(content string) (map[string]string, error) {
+	env, err := godotenv.Unmarshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse env file: %w", err)
+	}
+	return env, nil
+}
+
+// requiredVariableError signale une variable obligatoire (${VAR:?err}) non définie
+type requiredVariableError struct {
+	variable string
+	message  string
+}
+
+func (e *requiredVariableError) Error() string {
+	if e.message != "" {
+		return fmt.Sprintf("required variable %s is missing a value: %s", e.variable, e.message)
+	}
+	return fmt.Sprintf("required variable %s is missing a value", e.variable)
+}
+
+// interpolator applique l'interpolation de variables définie par la spécification Compose
+type interpolator struct {
+	environment map[string]string
+	unset       map[string]Diagnostic
+	file        string     // Fichier en cours d'interpolation
+	location    Diagnostic // Position de la valeur en cours d'interpolation
+}
+
+func newInterpolator(environment map[string]string) *interpolator {
+	return &interpolator{
+		environment: environment,
+		unset:       make(map[string]Diagnostic),
+	}
+}
+
+// lookup retourne la valeur d'une variable et indique si elle est définie
+func (in *interpolator) lookup(name string) (string, bool) {
+	value, ok := in.environment[name]
+	return value, ok
+}
+
+// substitute remplace toutes les références de variables dans une chaîne
+func (in *interpolator) substitute(value string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); {
+		if value[i] != '$' {
+			b.WriteByte(value[i])
+			i++
+			continue
+		}
+
+		if i+1 >= len(value) {
+			return "", fmt.Errorf("invalid interpolation format for %q: unexpected trailing '$'", value)
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			// $$ échappe un dollar littéral
+			b.WriteByte('$')
+			i += 2
+		case next == '{':
+			end := matchingBrace(value, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("invalid interpolation format for %q: missing closing brace", value)
+			}
+			expanded, err := in.expand(value[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(expanded)
+			i = end + 1
+		case isVariableStart(next):
+			j := i + 1
+			for j < len(value) && isVariableChar(value[j]) {
+				j++
+			}
+			b.WriteString(in.resolve(value[i+1 : j]))
+			i = j
+		default:
+			return "", fmt.Errorf("invalid interpolation format for %q: use '$$' for a literal '$'", value)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// expand évalue le contenu d'une expression ${...}
+func (in *interpolator) expand(expr string) (string, error) {
+	nameEnd := 0
+	for nameEnd < len(expr) && isVariableChar(expr[nameEnd]) {
+		nameEnd++
+	}
+
+	name := expr[:nameEnd]
+	if name == "" || !isVariableStart(name[0]) {
+		return "", fmt.Errorf("invalid interpolation format: ${%s}", expr)
+	}
+
+	rest := expr[nameEnd:]
+	if rest == "" {
+		return in.resolve(name), nil
+	}
+
+	value, set := in.lookup(name)
+
+	var operator string
+	for _, op := range []string{":-", ":?", ":+", "-", "?", "+"} {
+		if strings.HasPrefix(rest, op) {
+			operator = op
+			break
+		}
+	}
+	if operator == "" {
+		return "", fmt.Errorf("invalid interpolation format: ${%s}", expr)
+	}
+
+	operand := rest[len(operator):]
+
+	switch operator {
+	case ":-":
+		if !set || value == "" {
+			return in.substitute(operand)
+		}
+		return value, nil
+	case "-":
+		if !set {
+			return in.substitute(operand)
+		}
+		return value, nil
+	case ":?", "?":
+		if !set || (operator == ":?" && value == "") {
+			message, err := in.substitute(operand)
+			if err != nil {
+				return "", err
+			}
+			return "", &requiredVariableError{variable: name, message: message}
+		}
+		return value, nil
+	case ":+":
+		if set && value != "" {
+			return in.substitute(operand)
+		}
+		return "", nil
+	default: // "+"
+		if set {
+			return in.substitute(operand)
+		}
+		return "", nil
+	}
+}
+
+// resolve retourne la valeur d'une variable simple, ou une chaîne vide si elle n'est pas définie
+func (in *interpolator) resolve(name string) string {
+	value, ok := in.lookup(name)
+	if !ok {
+		if _, seen := in.unset[name]; !seen {
+			warning := in.location
+			warning.Code = "UNSET_VARIABLE"
+			warning.Message = fmt.Sprintf("The %q variable is not set. Defaulting to a blank string.", name)
+			warning.Suggestion = "Define the variable in the .env content supplied with the request"
+			in.unset[name] = warning
+		}
+	}
+	return value
+}
+
+// warnings retourne un avertissement par variable référencée mais non définie
+func (in *interpolator) warnings() []Diagnostic {
+	names := make([]string, 0, len(in.unset))
+	for name := range in.unset {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	warnings := make([]Diagnostic, 0, len(names))
+	for _, name := range names {
+		warnings = append(warnings, in.unset[name])
+	}
+	return warnings
+}
+
+// matchingBrace retourne l'index de l'accolade fermante correspondant à une expression ${...}
+func matchingBrace(value string, start int) int {
+	depth := 1
+	for i := start; i < len(value); i++ {
+		switch {
+		case value[i] == '$' && i+1 < len(value) && value[i+1] == '{':
+			depth++
+			i++
+		case value[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isVariableStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isVariableChar(c byte) bool {
+	return isVariableStart(c) || (c >= '0' && c <= '9')
+}
+
+// interpolateNode applique l'interpolation sur toutes les valeurs scalaires d'un arbre YAML
+func interpolateNode(node *yaml.Node, path []string, in *interpolator) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			diagnostics = append(diagnostics, interpolateNode(child, path, in)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			diagnostics = append(diagnostics, interpolateNode(node.Content[i+1], appendPath(path, key), in)...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			diagnostics = append(diagnostics, interpolateNode(child, appendPath(path, strconv.Itoa(i)), in)...)
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+
+		in.location = Diagnostic{
+			File:    in.file,
+			Service: serviceFromPath(path),
+			Field:   jsonPointer(path),
+			Line:    node.Line,
+			Column:  node.Column,
+		}
+
+		value, err := in.substitute(node.Value)
+		if err != nil {
+			diagnostic := in.location
+			diagnostic.Code = "INTERPOLATION_ERROR"
+			diagnostic.Message = err.Error()
+			if _, ok := err.(*requiredVariableError); ok {
+				diagnostic.Code = "MISSING_REQUIRED_VARIABLE"
+				diagnostic.Suggestion = "Define the variable in the .env content supplied with the request"
+			}
+			if diagnostic.Service != "" {
+				diagnostic.Message = fmt.Sprintf("service %s, field %s: %s", diagnostic.Service, diagnostic.Field, err)
+			}
+			return append(diagnostics, diagnostic)
+		}
+
+		node.Value = value
+		// Une valeur non quotée est re-typée après substitution (ex: "${REPLICAS}" -> 3).
+		// Les flottants restent des chaînes pour ne pas altérer des valeurs comme "1.10".
+		if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle|yaml.TaggedStyle) == 0 {
+			node.Tag = retypeScalar(value)
+		}
+	}
+
+	return diagnostics
+}
+
+// retypeScalar retourne le tag YAML d'une valeur interpolée non quotée
+func retypeScalar(value string) string {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return "!!int"
+	}
+	if value == "true" || value == "false" {
+		return "!!bool"
+	}
+	return "!!str"
+}
+
+// appendPath retourne une copie du chemin complétée d'un segment
+func appendPath(path []string, segment string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, segment)
+}
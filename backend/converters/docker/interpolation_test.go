@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolatorSubstitute(t *testing.T) {
+	in := newInterpolator(map[string]string{
+		"HOST":  "db",
+		"EMPTY": "",
+	})
+
+	cases := map[string]string{
+		"$HOST":                 "db",
+		"${HOST}:5432":          "db:5432",
+		"${MISSING:-localhost}": "localhost",
+		"${EMPTY:-fallback}":    "fallback",
+		"${EMPTY-fallback}":     "",
+		"${HOST:+set}":          "set",
+		"${EMPTY:+set}":         "",
+		"${EMPTY+set}":          "set",
+		"${A:-${B:-nested}}":    "nested",
+		"$$HOST":                "$HOST",
+		"price: $$5":            "price: $5",
+	}
+
+	for input, expected := range cases {
+		actual, err := in.substitute(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, actual, input)
+	}
+
+	_, err := in.substitute("${TAG:?must be set}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set")
+
+	_, err = in.substitute("${HOST")
+	assert.Error(t, err)
+}
+
+func TestParseDockerComposeWithOptionsInterpolation(t *testing.T) {
+	content := `services:
+  web:
+    image: nginx:${TAG:-latest}
+    deploy:
+      replicas: ${REPLICAS}
+  api:
+    image: ${API_IMAGE:?api image is required}
+`
+
+	_, _, err := ParseDockerComposeWithOptions(content, ParseOptions{
+		Environment: map[string]string{"REPLICAS": "3"},
+	})
+	var parseErrors ParseErrors
+	require.ErrorAs(t, err, &parseErrors)
+	require.Len(t, parseErrors, 1)
+	assert.Equal(t, "MISSING_REQUIRED_VARIABLE", parseErrors[0].Code)
+	assert.Equal(t, "api", parseErrors[0].Service)
+	assert.Equal(t, "/services/api/image", parseErrors[0].Field)
+	assert.Equal(t, 7, parseErrors[0].Line)
+
+	compose, warnings, err := ParseDockerComposeWithOptions(content, ParseOptions{
+		Environment: map[string]string{"REPLICAS": "3", "API_IMAGE": "api:1.0"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "nginx:latest", compose.Services["web"].Image)
+	assert.Equal(t, 3, compose.Services["web"].Deploy.Replicas)
+	assert.Equal(t, "api:1.0", compose.Services["api"].Image)
+}
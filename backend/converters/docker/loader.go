@@ -0,0 +1,478 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ressources de premier niveau importées depuis un fichier inclus
+var includedResources = []string{"services", "volumes", "networks", "configs", "secrets"}
+
+// loader charge les fichiers docker-compose et résout les clés include et extends
+// à partir des fichiers fournis avec la requête
+type loader struct {
+	files     map[string]string     // Fichiers disponibles, indexés par chemin normalisé
+	documents map[string]*yaml.Node // Documents déjà chargés (interpolés), par chemin normalisé
+	origins   map[*yaml.Node]string // Fichier d'origine de chaque nœud, pour l'index des positions
+	in        *interpolator
+	errs      ParseErrors
+}
+
+func newLoader(files map[string]string, in *interpolator) *loader {
+	l := &loader{
+		files:     make(map[string]string),
+		documents: make(map[string]*yaml.Node),
+		origins:   make(map[*yaml.Node]string),
+		in:        in,
+	}
+	for name, content := range files {
+		l.files[normalizeFilePath(name)] = content
+	}
+	return l
+}
+
+// loadModel parse, interpole et fusionne une liste ordonnée de fichiers,
+// puis résout les include et extends du modèle obtenu
+func (l *loader) loadModel(files []ComposeFile, dir string, includeStack []string) (*yaml.Node, error) {
+	var merged *yaml.Node
+
+	for _, file := range files {
+		document, err := l.parseDocument(file.Name, file.Content)
+		if err != nil {
+			return nil, err
+		}
+		if document == nil {
+			continue
+		}
+		merged = mergeNodes(merged, document, nil)
+	}
+
+	if merged == nil || merged.Kind != yaml.MappingNode {
+		return merged, nil
+	}
+
+	// Le nom de fichier n'est connu avec certitude que pour un modèle mono-fichier
+	file := ""
+	if len(files) == 1 {
+		file = files[0].Name
+	}
+
+	l.resolveIncludes(merged, file, dir, includeStack)
+	l.resolveExtends(merged, file, dir)
+
+	return merged, nil
+}
+
+// parseDocument parse et interpole un document ; un document vide retourne nil
+func (l *loader) parseDocument(name, content string) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return nil, ParseErrors{syntaxError(name, err)}
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	// Interpoler les variables avant de fusionner et décoder la structure
+	l.in.file = name
+	l.errs = append(l.errs, interpolateNode(&document, nil, l.in)...)
+	recordOrigins(document.Content[0], name, l.origins)
+
+	return document.Content[0], nil
+}
+
+// loadFile charge un fichier fourni avec la requête, en le mettant en cache
+func (l *loader) loadFile(name string) (*yaml.Node, bool, error) {
+	key := normalizeFilePath(name)
+	if document, ok := l.documents[key]; ok {
+		return document, true, nil
+	}
+
+	content, ok := l.files[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	document, err := l.parseDocument(name, content)
+	if err != nil {
+		return nil, true, err
+	}
+	l.documents[key] = document
+	return document, true, nil
+}
+
+// resolveIncludes charge les modèles référencés par la clé include et importe leurs ressources
+func (l *loader) resolveIncludes(model *yaml.Node, file, dir string, includeStack []string) {
+	index := mappingIndex(model, "include")
+	if index < 0 {
+		return
+	}
+	includeNode := model.Content[index+1]
+	model.Content = append(model.Content[:index], model.Content[index+2:]...)
+
+	location := Diagnostic{File: file, Field: "/include", Line: includeNode.Line, Column: includeNode.Column}
+	if includeNode.Kind != yaml.SequenceNode {
+		location.Code = "INVALID_INCLUDE"
+		location.Message = "include must be a list"
+		l.errs = append(l.errs, location)
+		return
+	}
+
+	for i, entry := range includeNode.Content {
+		location := Diagnostic{
+			File:   file,
+			Field:  jsonPointer([]string{"include", fmt.Sprint(i)}),
+			Line:   entry.Line,
+			Column: entry.Column,
+		}
+
+		paths, projectDir, envFiles, err := parseIncludeEntry(entry)
+		if err != nil {
+			location.Code = "INVALID_INCLUDE"
+			location.Message = err.Error()
+			l.errs = append(l.errs, location)
+			continue
+		}
+
+		// Résoudre les chemins relativement au fichier qui déclare l'include
+		var includeFiles []ComposeFile
+		for _, includePath := range paths {
+			resolved := resolveFilePath(dir, includePath)
+
+			if cycle := cyclePath(includeStack, resolved); cycle != "" {
+				location.Code = "INCLUDE_CYCLE"
+				location.Message = fmt.Sprintf("include cycle detected: %s", cycle)
+				l.errs = append(l.errs, location)
+				includeFiles = nil
+				break
+			}
+
+			content, ok := l.files[resolved]
+			if !ok {
+				location.Code = "INCLUDE_NOT_FOUND"
+				location.Message = fmt.Sprintf("included file %s was not supplied with the request", includePath)
+				location.Suggestion = "Add the file to the request bundle using its relative path as name"
+				l.errs = append(l.errs, location)
+				includeFiles = nil
+				break
+			}
+			includeFiles = append(includeFiles, ComposeFile{Name: resolved, Content: content})
+		}
+		if len(includeFiles) == 0 {
+			continue
+		}
+
+		includeDir := path.Dir(includeFiles[0].Name)
+		if projectDir != "" {
+			includeDir = resolveFilePath(dir, projectDir)
+		}
+
+		restore, ok := l.applyIncludeEnvFiles(dir, envFiles, location)
+		if !ok {
+			continue
+		}
+
+		stack := append(append([]string(nil), includeStack...), includeFiles[0].Name)
+		included, err := l.loadModel(includeFiles, includeDir, stack)
+		restore()
+		if err != nil {
+			l.errs = append(l.errs, toParseErrors(err, location)...)
+			continue
+		}
+		if included == nil {
+			continue
+		}
+
+		l.importResources(model, included, file, location)
+	}
+}
+
+// applyIncludeEnvFiles ajoute les variables des env_file d'un include à l'interpolation.
+// Les variables fournies par l'appelant restent prioritaires. La fonction retournée restaure l'environnement.
+func (l *loader) applyIncludeEnvFiles(dir string, envFiles []string, location Diagnostic) (func(), bool) {
+	previous := l.in.environment
+	restore := func() { l.in.environment = previous }
+	if len(envFiles) == 0 {
+		return restore, true
+	}
+
+	environment := make(map[string]string)
+	for _, envFile := range envFiles {
+		content, ok := l.files[resolveFilePath(dir, envFile)]
+		if !ok {
+			location.Code = "INCLUDE_NOT_FOUND"
+			location.Message = fmt.Sprintf("env_file %s was not supplied with the request", envFile)
+			l.errs = append(l.errs, location)
+			return restore, false
+		}
+		values, err := ParseEnvFile(content)
+		if err != nil {
+			location.Code = "ENV_FILE_ERROR"
+			location.Message = fmt.Sprintf("invalid env_file %s: %v", envFile, err)
+			l.errs = append(l.errs, location)
+			return restore, false
+		}
+		for k, v := range values {
+			environment[k] = v
+		}
+	}
+	for k, v := range previous {
+		environment[k] = v
+	}
+
+	l.in.environment = environment
+	return restore, true
+}
+
+// importResources ajoute au modèle les ressources d'un modèle inclus.
+// Un service déjà défini est un conflit ; les autres ressources existantes sont conservées.
+func (l *loader) importResources(model, included *yaml.Node, file string, location Diagnostic) {
+	for _, resource := range includedResources {
+		index := mappingIndex(included, resource)
+		if index < 0 || included.Content[index+1].Kind != yaml.MappingNode {
+			continue
+		}
+		source := included.Content[index+1]
+
+		targetIndex := mappingIndex(model, resource)
+		if targetIndex < 0 {
+			model.Content = append(model.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: resource},
+				&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+			targetIndex = len(model.Content) - 2
+		}
+		target := model.Content[targetIndex+1]
+		if target.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(source.Content); i += 2 {
+			name := source.Content[i].Value
+			if existing := mappingIndex(target, name); existing >= 0 {
+				if resource == "services" {
+					conflict := location
+					conflict.Code = "INCLUDE_CONFLICT"
+					conflict.Service = name
+					conflict.Message = fmt.Sprintf("service %s from included file conflicts with a service defined in %s", name, displayFileName(file))
+					l.errs = append(l.errs, conflict)
+				}
+				continue
+			}
+			target.Content = append(target.Content, source.Content[i], source.Content[i+1])
+		}
+	}
+}
+
+// resolveExtends résout la clé extends de chaque service du modèle
+func (l *loader) resolveExtends(model *yaml.Node, file, dir string) {
+	index := mappingIndex(model, "services")
+	if index < 0 || model.Content[index+1].Kind != yaml.MappingNode {
+		return
+	}
+	services := model.Content[index+1]
+
+	resolved := make(map[string]bool)
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name := services.Content[i].Value
+		services.Content[i+1] = l.extendService(services, name, file, dir, nil, resolved)
+	}
+}
+
+// extendService retourne la définition du service fusionnée avec celle qu'il étend.
+// stack contient la chaîne des services en cours de résolution ("fichier#service") pour détecter les cycles.
+func (l *loader) extendService(services *yaml.Node, name, file, dir string, stack []string, resolved map[string]bool) *yaml.Node {
+	index := mappingIndex(services, name)
+	service := services.Content[index+1]
+	if resolved[name] || service.Kind != yaml.MappingNode {
+		return service
+	}
+
+	extendsIndex := mappingIndex(service, "extends")
+	if extendsIndex < 0 {
+		resolved[name] = true
+		return service
+	}
+	extendsNode := service.Content[extendsIndex+1]
+
+	// La définition locale, sans la clé extends, surcharge la définition de base
+	local := *service
+	local.Content = append(append([]*yaml.Node(nil), service.Content[:extendsIndex]...), service.Content[extendsIndex+2:]...)
+
+	location := Diagnostic{
+		File:    file,
+		Service: name,
+		Field:   jsonPointer([]string{"services", name, "extends"}),
+		Line:    extendsNode.Line,
+		Column:  extendsNode.Column,
+	}
+
+	baseName, baseFile, err := parseExtends(extendsNode)
+	if err != nil {
+		location.Code = "INVALID_EXTENDS"
+		location.Message = fmt.Sprintf("service %s: %v", name, err)
+		l.errs = append(l.errs, location)
+		return &local
+	}
+
+	key := displayFileName(file) + "#" + name
+	stack = append(append([]string(nil), stack...), key)
+
+	// Service de base dans le même document
+	baseServices, baseFileName, baseDir := services, file, dir
+	if baseFile != "" {
+		baseFileName = resolveFilePath(dir, baseFile)
+		baseDir = path.Dir(baseFileName)
+
+		document, found, err := l.loadFile(baseFileName)
+		switch {
+		case err != nil:
+			l.errs = append(l.errs, toParseErrors(err, location)...)
+			return &local
+		case !found:
+			location.Code = "EXTENDS_NOT_FOUND"
+			location.Message = fmt.Sprintf("service %s extends a service from %s, which was not supplied with the request", name, baseFile)
+			location.Suggestion = "Add the file to the request bundle using its relative path as name"
+			l.errs = append(l.errs, location)
+			return &local
+		}
+
+		baseServices = nil
+		if document != nil {
+			if servicesIndex := mappingIndex(document, "services"); servicesIndex >= 0 {
+				baseServices = document.Content[servicesIndex+1]
+			}
+		}
+	}
+
+	if baseServices == nil || baseServices.Kind != yaml.MappingNode || mappingIndex(baseServices, baseName) < 0 {
+		location.Code = "EXTENDS_NOT_FOUND"
+		location.Message = fmt.Sprintf("service %s extends undefined service %s", name, baseName)
+		if baseFile != "" {
+			location.Message += " in " + baseFile
+		}
+		l.errs = append(l.errs, location)
+		return &local
+	}
+
+	baseKey := displayFileName(baseFileName) + "#" + baseName
+	if cycle := cyclePath(stack, baseKey); cycle != "" {
+		location.Code = "EXTENDS_CYCLE"
+		location.Message = fmt.Sprintf("service %s: extends cycle detected: %s", name, cycle)
+		l.errs = append(l.errs, location)
+		return &local
+	}
+
+	// Résoudre d'abord le service de base (qui peut lui-même étendre un autre service)
+	var base *yaml.Node
+	if baseFile == "" {
+		base = l.extendService(baseServices, baseName, baseFileName, baseDir, stack, resolved)
+		baseServices.Content[mappingIndex(baseServices, baseName)+1] = base
+	} else {
+		base = l.extendService(baseServices, baseName, baseFileName, baseDir, stack, make(map[string]bool))
+	}
+
+	result := mergeNodes(base, &local, []string{"services", name})
+	resolved[name] = true
+	return result
+}
+
+// toParseErrors retourne les diagnostics d'une erreur, ou un diagnostic PARSE_ERROR à la position donnée
+func toParseErrors(err error, location Diagnostic) ParseErrors {
+	var parseErrors ParseErrors
+	if errors.As(err, &parseErrors) {
+		return parseErrors
+	}
+	location.Code = "PARSE_ERROR"
+	location.Message = err.Error()
+	return ParseErrors{location}
+}
+
+// parseExtends lit la clé extends : "service" ou {service, file}
+func parseExtends(node *yaml.Node) (string, string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" {
+			return "", "", fmt.Errorf("extends requires a service name")
+		}
+		return node.Value, "", nil
+	case yaml.MappingNode:
+		var extends struct {
+			Service string `yaml:"service"`
+			File    string `yaml:"file"`
+		}
+		if err := node.Decode(&extends); err != nil {
+			return "", "", err
+		}
+		if extends.Service == "" {
+			return "", "", fmt.Errorf("extends requires a service name")
+		}
+		return extends.Service, extends.File, nil
+	default:
+		return "", "", fmt.Errorf("extends must be a service name or a mapping")
+	}
+}
+
+// parseIncludeEntry lit une entrée include : "chemin" ou {path, project_directory, env_file}
+func parseIncludeEntry(node *yaml.Node) ([]string, string, []string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" {
+			return nil, "", nil, fmt.Errorf("include path cannot be empty")
+		}
+		return []string{node.Value}, "", nil, nil
+	case yaml.MappingNode:
+		var entry struct {
+			Path             StringList `yaml:"path"`
+			ProjectDirectory string     `yaml:"project_directory"`
+			EnvFile          StringList `yaml:"env_file"`
+		}
+		if err := node.Decode(&entry); err != nil {
+			return nil, "", nil, err
+		}
+		if len(entry.Path) == 0 {
+			return nil, "", nil, fmt.Errorf("include requires a path")
+		}
+		return entry.Path, entry.ProjectDirectory, entry.EnvFile, nil
+	default:
+		return nil, "", nil, fmt.Errorf("include entry must be a path or a mapping")
+	}
+}
+
+// cyclePath retourne la description du cycle si key est déjà présent dans la pile, sinon ""
+func cyclePath(stack []string, key string) string {
+	for i, entry := range stack {
+		if entry == key {
+			return strings.Join(append(append([]string(nil), stack[i:]...), key), " -> ")
+		}
+	}
+	return ""
+}
+
+// normalizeFilePath normalise un chemin de fichier fourni avec la requête
+func normalizeFilePath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = path.Clean(name)
+	return strings.TrimPrefix(name, "/")
+}
+
+// resolveFilePath résout un chemin relativement au répertoire du fichier qui le référence
+func resolveFilePath(dir, name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") {
+		return normalizeFilePath(name)
+	}
+	return normalizeFilePath(path.Join(dir, name))
+}
+
+// displayFileName retourne le nom affiché d'un fichier dans les messages
+func displayFileName(file string) string {
+	if file == "" {
+		return "docker-compose.yml"
+	}
+	return file
+}
@@ -0,0 +1,126 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerComposeExtends(t *testing.T) {
+	main := `services:
+  base:
+    image: node:20
+    environment:
+      NODE_ENV: production
+    ports:
+      - "3000:3000"
+  api:
+    extends: base
+    environment:
+      PORT: "3000"
+  worker:
+    extends:
+      file: common/services.yml
+      service: worker
+    command: ["node", "worker.js"]
+`
+	common := `services:
+  worker:
+    image: node:20-alpine
+    restart: always
+`
+
+	compose, _, err := ParseDockerComposeFiles([]ComposeFile{{Name: "docker-compose.yml", Content: main}}, ParseOptions{
+		Files: map[string]string{"./common/services.yml": common},
+	})
+	require.NoError(t, err)
+
+	api := compose.Services["api"]
+	assert.Equal(t, "node:20", api.Image)
+	assert.Equal(t, map[string]string{"NODE_ENV": "production", "PORT": "3000"}, api.Environment)
+	require.Len(t, api.Ports, 1)
+
+	worker := compose.Services["worker"]
+	assert.Equal(t, "node:20-alpine", worker.Image)
+	assert.Equal(t, "always", worker.Restart)
+	assert.Equal(t, []string{"node", "worker.js"}, worker.Command)
+}
+
+func TestParseDockerComposeInclude(t *testing.T) {
+	main := `include:
+  - path: infra/db.yml
+    env_file: infra/.env
+services:
+  web:
+    image: nginx
+`
+	db := `services:
+  db:
+    image: postgres:${PG_VERSION}
+volumes:
+  pgdata: {}
+`
+
+	compose, _, err := ParseDockerComposeFiles([]ComposeFile{{Name: "docker-compose.yml", Content: main}}, ParseOptions{
+		Files: map[string]string{"infra/db.yml": db, "infra/.env": "PG_VERSION=16\n"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, compose.Services, "web")
+	assert.Equal(t, "postgres:16", compose.Services["db"].Image)
+	assert.Contains(t, compose.Volumes, "pgdata")
+}
+
+func TestParseDockerComposeCycles(t *testing.T) {
+	cases := []struct {
+		name  string
+		main  string
+		files map[string]string
+		code  string
+	}{
+		{
+			name: "extends",
+			main: `services:
+  a:
+    extends: b
+  b:
+    extends: a
+`,
+			code: "EXTENDS_CYCLE",
+		},
+		{
+			name: "include",
+			main: `include:
+  - other.yml
+services:
+  web:
+    image: nginx
+`,
+			files: map[string]string{"other.yml": "include:\n  - docker-compose.yml\n"},
+			code:  "INCLUDE_CYCLE",
+		},
+		{
+			name: "missing",
+			main: `services:
+  web:
+    extends:
+      file: missing.yml
+      service: web
+`,
+			code: "EXTENDS_NOT_FOUND",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := ParseDockerComposeFiles([]ComposeFile{{Name: "docker-compose.yml", Content: tc.main}}, ParseOptions{Files: tc.files})
+
+			var parseErrors ParseErrors
+			require.True(t, errors.As(err, &parseErrors), "expected ParseErrors, got %v", err)
+			assert.Equal(t, tc.code, parseErrors[0].Code)
+			assert.NotZero(t, parseErrors[0].Line)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package docker
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tags YAML contrôlant la fusion des fichiers Compose
+const (
+	resetTag    = "!reset"
+	overrideTag = "!override"
+)
+
+// Stratégies de fusion des séquences
+const (
+	mergeAppend  = "append"  // concaténation sans doublons (ports, expose, dns...)
+	mergeReplace = "replace" // l'override remplace la valeur (command, entrypoint...)
+	mergeMapping = "mapping" // fusion clé par clé ("KEY=VALUE" ou map)
+	mergeTarget  = "target"  // fusion par chemin cible (volumes, secrets...)
+)
+
+// mergeNodes fusionne un nœud d'override dans un nœud de base selon les règles Compose :
+// les maps sont fusionnées, les séquences concaténées ou fusionnées selon la clé,
+// et les tags !reset / !override sont appliqués.
+func mergeNodes(base, override *yaml.Node, path []string) *yaml.Node {
+	if base == nil {
+		return resolveMergeTags(override)
+	}
+	if override == nil {
+		return base
+	}
+
+	if override.Tag == overrideTag {
+		return resolveMergeTags(override)
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		return mergeMappingNodes(base, override, path)
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode,
+		sequenceMergeStrategy(path) == mergeMapping:
+		return mergeSequenceNodes(base, override, path)
+	default:
+		return resolveMergeTags(override)
+	}
+}
+
+// mergeMappingNodes fusionne deux maps clé par clé
+func mergeMappingNodes(base, override *yaml.Node, path []string) *yaml.Node {
+	result := *base
+	result.Content = append([]*yaml.Node(nil), base.Content...)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+		index := mappingIndex(&result, key.Value)
+
+		if value.Tag == resetTag {
+			// !reset supprime la valeur héritée des fichiers précédents
+			if index >= 0 {
+				result.Content = append(result.Content[:index], result.Content[index+2:]...)
+			}
+			continue
+		}
+
+		if index < 0 {
+			result.Content = append(result.Content, key, resolveMergeTags(value))
+			continue
+		}
+
+		result.Content[index+1] = mergeNodes(result.Content[index+1], value, appendPath(path, key.Value))
+	}
+
+	return &result
+}
+
+// mergeSequenceNodes fusionne deux séquences selon la stratégie associée au chemin
+func mergeSequenceNodes(base, override *yaml.Node, path []string) *yaml.Node {
+	switch sequenceMergeStrategy(path) {
+	case mergeReplace:
+		return resolveMergeTags(override)
+	case mergeMapping:
+		return mergeMappingNodes(sequenceToMapping(base), sequenceToMapping(override), path)
+	case mergeTarget:
+		result := *base
+		result.Content = append([]*yaml.Node(nil), base.Content...)
+		for _, item := range override.Content {
+			item = resolveMergeTags(item)
+			replaced := false
+			for j, existing := range result.Content {
+				if mergeKey(existing) != "" && mergeKey(existing) == mergeKey(item) {
+					result.Content[j] = item
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				result.Content = append(result.Content, item)
+			}
+		}
+		return &result
+	default:
+		result := *base
+		result.Content = append([]*yaml.Node(nil), base.Content...)
+		for _, item := range override.Content {
+			if item.Kind == yaml.ScalarNode && containsScalar(&result, item.Value) {
+				continue
+			}
+			result.Content = append(result.Content, resolveMergeTags(item))
+		}
+		return &result
+	}
+}
+
+// sequenceMergeStrategy retourne la stratégie de fusion d'une séquence selon son chemin
+func sequenceMergeStrategy(path []string) string {
+	if len(path) < 3 || path[0] != "services" {
+		return mergeAppend
+	}
+
+	field := strings.Join(path[2:], ".")
+	switch field {
+	case "command", "entrypoint", "healthcheck.test":
+		return mergeReplace
+	case "environment", "labels", "annotations", "sysctls", "build.args", "build.labels", "deploy.labels":
+		return mergeMapping
+	case "volumes", "devices", "secrets", "configs":
+		return mergeTarget
+	default:
+		return mergeAppend
+	}
+}
+
+// sequenceToMapping convertit une liste "KEY=VALUE" en map ; une map est retournée telle quelle
+func sequenceToMapping(node *yaml.Node) *yaml.Node {
+	if node.Kind != yaml.SequenceNode {
+		return node
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: node.Line, Column: node.Column}
+	for _, item := range node.Content {
+		key, value, hasValue := strings.Cut(item.Value, "=")
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, Line: item.Line, Column: item.Column}
+		valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: item.Line, Column: item.Column}
+		if !hasValue {
+			valueNode.Tag = "!!null"
+		}
+
+		if index := mappingIndex(mapping, key); index >= 0 {
+			mapping.Content[index+1] = valueNode
+			continue
+		}
+		mapping.Content = append(mapping.Content, keyNode, valueNode)
+	}
+
+	return mapping
+}
+
+// mergeKey retourne la clé d'identification d'un montage (chemin cible, sinon source)
+func mergeKey(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		parts := splitVolumeSpec(node.Value)
+		if len(parts) >= 2 {
+			return parts[1]
+		}
+		return node.Value
+	case yaml.MappingNode:
+		if index := mappingIndex(node, "target"); index >= 0 {
+			return node.Content[index+1].Value
+		}
+		if index := mappingIndex(node, "source"); index >= 0 {
+			return node.Content[index+1].Value
+		}
+	}
+	return ""
+}
+
+// resolveMergeTags supprime récursivement les clés marquées !reset et les tags !override
+func resolveMergeTags(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if node.Tag == overrideTag {
+		node.Tag = ""
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		content := node.Content[:0]
+		for _, child := range node.Content {
+			if child.Tag == resetTag {
+				continue
+			}
+			content = append(content, resolveMergeTags(child))
+		}
+		node.Content = content
+	case yaml.MappingNode:
+		content := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i+1].Tag == resetTag {
+				continue
+			}
+			content = append(content, node.Content[i], resolveMergeTags(node.Content[i+1]))
+		}
+		node.Content = content
+	}
+
+	return node
+}
+
+// mappingIndex retourne l'index de la clé dans une map YAML, ou -1
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsScalar indique si une séquence contient déjà une valeur scalaire
+func containsScalar(node *yaml.Node, value string) bool {
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode && item.Value == value {
+			return true
+		}
+	}
+	return false
+}
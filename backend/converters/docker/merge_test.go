@@ -0,0 +1,71 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerComposeFilesMerge(t *testing.T) {
+	base := `services:
+  web:
+    image: nginx:1.25
+    command: ["nginx", "-g", "daemon off;"]
+    environment:
+      - LOG_LEVEL=info
+      - WORKERS=2
+    ports:
+      - "80:80"
+    volumes:
+      - ./html:/usr/share/nginx/html
+    labels:
+      tier: frontend
+      debug: "true"
+  db:
+    image: postgres:16
+    ports:
+      - "5432:5432"
+`
+	override := `services:
+  web:
+    image: nginx:1.27
+    command: ["nginx-debug"]
+    environment:
+      WORKERS: "4"
+    ports:
+      - "443:443"
+    volumes:
+      - ./site:/usr/share/nginx/html:ro
+    labels:
+      debug: !reset null
+  db:
+    ports: !override
+      - "15432:5432"
+`
+
+	compose, warnings, err := ParseDockerComposeFiles([]ComposeFile{
+		{Name: "docker-compose.yml", Content: base},
+		{Name: "docker-compose.override.yml", Content: override},
+	}, ParseOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	web := compose.Services["web"]
+	assert.Equal(t, "nginx:1.27", web.Image)
+	assert.Equal(t, []string{"nginx-debug"}, web.Command)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "info", "WORKERS": "4"}, web.Environment)
+	assert.Equal(t, LabelMap{"tier": "frontend"}, web.Labels)
+
+	require.Len(t, web.Ports, 2)
+	assert.Equal(t, 80, web.Ports[0].Target)
+	assert.Equal(t, 443, web.Ports[1].Target)
+
+	require.Len(t, web.Volumes, 1)
+	assert.Equal(t, "./site", web.Volumes[0].Source)
+	assert.True(t, web.Volumes[0].ReadOnly)
+
+	db := compose.Services["db"]
+	require.Len(t, db.Ports, 1)
+	assert.Equal(t, "15432", db.Ports[0].Published)
+}
@@ -2,19 +2,97 @@ package docker
 
 import (
 	"fmt"
-	"strconv"
+	"path"
+	"sort"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
+// ParseOptions options de parsing d'un fichier docker-compose
+type ParseOptions struct {
+	// Environment contient les variables utilisées pour l'interpolation (${VAR}).
+	// Seules les variables fournies par l'appelant sont visibles, jamais l'environnement du serveur.
+	Environment map[string]string
+
+	// Files contient les fichiers supplémentaires fournis avec la requête, indexés par chemin relatif.
+	// Ils servent à résoudre les clés extends (file) et include.
+	Files map[string]string
+}
+
 // ParseDockerCompose parse un fichier docker-compose.yml
 func ParseDockerCompose(content string) (*DockerCompose, error) {
+	compose, _, err := ParseDockerComposeWithOptions(content, ParseOptions{})
+	return compose, err
+}
+
+// ComposeFile représente un document docker-compose fourni à la conversion
+type ComposeFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// ParseDockerComposeWithOptions parse un fichier docker-compose.yml en appliquant l'interpolation
+// des variables. Les avertissements (variables non définies, etc.) sont retournés séparément ;
+// les erreurs localisées sont retournées sous forme de ParseErrors.
+func ParseDockerComposeWithOptions(content string, options ParseOptions) (*DockerCompose, []Diagnostic, error) {
+	return ParseDockerComposeFiles([]ComposeFile{{Content: content}}, options)
+}
+
+// ParseDockerComposeFiles parse une liste ordonnée de fichiers docker-compose (base puis overrides)
+// et les fusionne selon les règles Compose avant de décoder le modèle.
+func ParseDockerComposeFiles(files []ComposeFile, options ParseOptions) (*DockerCompose, []Diagnostic, error) {
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no docker-compose file provided")
+	}
+
+	in := newInterpolator(options.Environment)
+
+	// Les fichiers principaux sont aussi accessibles aux références extends et include
+	bundle := make(map[string]string, len(options.Files)+len(files))
+	for name, content := range options.Files {
+		bundle[name] = content
+	}
+	for _, file := range files {
+		if file.Name != "" {
+			bundle[file.Name] = file.Content
+		}
+	}
+
+	l := newLoader(bundle, in)
+	dir := "."
+	if files[0].Name != "" {
+		dir = path.Dir(normalizeFilePath(files[0].Name))
+	}
+
+	merged, err := l.loadModel(files, dir, []string{normalizeFilePath(displayFileName(files[0].Name))})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(l.errs) > 0 {
+		return nil, in.warnings(), l.errs
+	}
+
+	// Index des positions, pour localiser les erreurs et avertissements
+	sourceMap := buildSourceMap(merged, l.origins)
+
+	// Valider le modèle fusionné contre le schéma Compose
+	if merged != nil {
+		violations, err := validateSchema(merged, sourceMap)
+		if err != nil {
+			return nil, in.warnings(), err
+		}
+		if len(violations) > 0 {
+			return nil, in.warnings(), violations
+		}
+	}
+
 	var compose DockerCompose
-	
-	if err := yaml.Unmarshal([]byte(content), &compose); err != nil {
-		return nil, fmt.Errorf("failed to parse docker-compose file: %w", err)
+	if merged != nil {
+		if err := merged.Decode(&compose); err != nil {
+			return nil, in.warnings(), decodeErrors(err, sourceMap)
+		}
 	}
+	compose.Positions = sourceMap
 
 	// Valider la version
 	if compose.Version == "" {
@@ -22,89 +100,87 @@ func ParseDockerCompose(content string) (*DockerCompose, error) {
 	}
 
 	// Normaliser les services
-	if err := normalizeServices(&compose); err != nil {
-		return nil, fmt.Errorf("failed to normalize services: %w", err)
+	if errs := normalizeServices(&compose); len(errs) > 0 {
+		for i := range errs {
+			sourceMap.Locate(&errs[i])
+		}
+		return nil, in.warnings(), errs
 	}
 
-	return &compose, nil
+	// Résoudre le contenu des secrets et configs fournis avec la requête
+	resolveResourceData(&compose, l.files, dir, options.Environment)
+
+	return &compose, in.warnings(), nil
 }
 
 // normalizeServices normalise la structure des services
-func normalizeServices(compose *DockerCompose) error {
-	for serviceName, service := range compose.Services {
-		// Normaliser les ports
-		normalizedPorts, err := normalizePorts(service.Ports)
-		if err != nil {
-			return fmt.Errorf("failed to normalize ports for service %s: %w", serviceName, err)
-		}
-		service.Ports = normalizedPorts
+func normalizeServices(compose *DockerCompose) ParseErrors {
+	var errs ParseErrors
+	invalid := func(serviceName, field string, err error) {
+		errs = append(errs, Diagnostic{
+			Code:    "INVALID_FIELD",
+			Message: fmt.Sprintf("service %s, field %s: %v", serviceName, field, err),
+			Service: serviceName,
+			Field:   ServiceField(serviceName, field),
+		})
+	}
 
+	for serviceName, service := range compose.Services {
 		// Normaliser les variables d'environnement
 		normalizedEnv, err := normalizeEnvironment(service.Environment)
 		if err != nil {
-			return fmt.Errorf("failed to normalize environment for service %s: %w", serviceName, err)
+			invalid(serviceName, "environment", err)
 		}
 		service.Environment = normalizedEnv
 
 		// Normaliser les réseaux
 		normalizedNetworks, err := normalizeNetworks(service.Networks)
 		if err != nil {
-			return fmt.Errorf("failed to normalize networks for service %s: %w", serviceName, err)
+			invalid(serviceName, "networks", err)
 		}
 		service.Networks = normalizedNetworks
 
 		// Normaliser depends_on
 		normalizedDeps, err := normalizeDependsOn(service.DependsOn)
 		if err != nil {
-			return fmt.Errorf("failed to normalize depends_on for service %s: %w", serviceName, err)
+			invalid(serviceName, "depends_on", err)
 		}
 		service.DependsOn = normalizedDeps
 
-		// Normaliser les commandes
-		service.Command = normalizeCommand(service.Command)
-		service.Entrypoint = normalizeCommand(service.Entrypoint)
-
-		// Mettre à jour le service dans la map
-		compose.Services[serviceName] = service
-	}
-
-	return nil
-}
+		// Normaliser les sysctls (même formats que environment)
+		normalizedSysctls, err := normalizeEnvironment(service.Sysctls)
+		if err != nil {
+			invalid(serviceName, "sysctls", err)
+		}
+		if normalizedSysctls != nil {
+			service.Sysctls = normalizedSysctls
+		}
 
-// normalizePorts normalise les définitions de ports
-func normalizePorts(ports []string) ([]string, error) {
-	var normalized []string
-	
-	for _, port := range ports {
-		// Valider le format du port
-		if strings.Contains(port, ":") {
-			parts := strings.Split(port, ":")
-			if len(parts) < 2 || len(parts) > 3 {
-				return nil, fmt.Errorf("invalid port format: %s", port)
-			}
-			
-			// Valider que les parties sont des nombres valides
-			for i, part := range parts {
-				if part == "" {
-					continue
-				}
-				if i > 0 { // Skip protocol part for host:container:protocol format
-					if _, err := strconv.Atoi(part); err != nil {
-						return nil, fmt.Errorf("invalid port number: %s", part)
-					}
-				}
+		// Normaliser les commandes ; une liste vide est conservée (réinitialisation de l'image)
+		if service.Command != nil {
+			command, err := normalizeCommand(service.Command)
+			if err != nil {
+				invalid(serviceName, "command", err)
 			}
-		} else {
-			// Port simple
-			if _, err := strconv.Atoi(port); err != nil {
-				return nil, fmt.Errorf("invalid port number: %s", port)
+			service.Command = command
+		}
+		if service.Entrypoint != nil {
+			entrypoint, err := normalizeCommand(service.Entrypoint)
+			if err != nil {
+				invalid(serviceName, "entrypoint", err)
 			}
+			service.Entrypoint = entrypoint
 		}
-		
-		normalized = append(normalized, port)
+
+		// Vérifier les références aux secrets et configs
+		errs = append(errs, normalizeFileReferences(compose, serviceName, &service)...)
+
+		// Mettre à jour le service dans la map
+		compose.Services[serviceName] = service
 	}
-	
-	return normalized, nil
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
 }
 
 // normalizeEnvironment normalise les variables d'environnement
@@ -117,6 +193,10 @@ func normalizeEnvironment(env interface{}) (map[string]string, error) {
 	case map[string]interface{}:
 		result := make(map[string]string)
 		for k, v := range e {
+			if v == nil {
+				result[k] = ""
+				continue
+			}
 			result[k] = fmt.Sprintf("%v", v)
 		}
 		return result, nil
@@ -259,27 +339,26 @@ func normalizeDependsOn(deps interface{}) (map[string]DependencyConfig, error) {
 	}
 }
 
-// normalizeCommand normalise les commandes et entrypoints
-func normalizeCommand(cmd interface{}) []string {
-	if cmd == nil {
-		return nil
-	}
-
+// normalizeCommand normalise les commandes et entrypoints en liste d'arguments.
+// Une chaîne est découpée comme par un shell (guillemets et échappements), sans être interprétée ;
+// une chaîne ou une liste vide donne une liste vide non nil.
+func normalizeCommand(cmd interface{}) ([]string, error) {
 	switch c := cmd.(type) {
 	case string:
-		// Commande simple en string - la diviser en arguments
-		return strings.Fields(c)
+		return SplitShellWords(c)
 	case []interface{}:
-		var result []string
+		result := make([]string, 0, len(c))
 		for _, item := range c {
-			if str, ok := item.(string); ok {
-				result = append(result, str)
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid command argument %v: expected a string", item)
 			}
+			result = append(result, str)
 		}
-		return result
+		return result, nil
 	case []string:
-		return c
+		return c, nil
 	default:
-		return nil
+		return nil, fmt.Errorf("unsupported command format: %T", cmd)
 	}
 }
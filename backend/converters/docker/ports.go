@@ -0,0 +1,224 @@
+package docker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PortConfig représente un port publié par un service (syntaxe courte ou longue)
+type PortConfig struct {
+	Name        string `yaml:"name,omitempty"`
+	Target      int    `yaml:"target"`
+	Published   string `yaml:"published,omitempty"` // numéro ou plage ("8000-8010")
+	HostIP      string `yaml:"host_ip,omitempty"`
+	Protocol    string `yaml:"protocol,omitempty"`
+	AppProtocol string `yaml:"app_protocol,omitempty"`
+	Mode        string `yaml:"mode,omitempty"`
+}
+
+// PublishedPort retourne le premier port hôte publié, ou 0 s'il n'y en a pas
+func (p PortConfig) PublishedPort() int {
+	if p.Published == "" {
+		return 0
+	}
+	start, _, err := parsePortRange(p.Published)
+	if err != nil {
+		return 0
+	}
+	return start
+}
+
+// PortList représente la liste des ports d'un service. Les plages de la syntaxe
+// courte sont dépliées en une entrée par port.
+type PortList []PortConfig
+
+// UnmarshalYAML décode les syntaxes courte ("8080:80/udp") et longue (target, published...)
+func (l *PortList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: ports must be a list", value.Line)
+	}
+
+	var ports PortList
+	for _, item := range value.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			parsed, err := ParsePortMapping(item.Value)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			ports = append(ports, parsed...)
+		case yaml.MappingNode:
+			var port PortConfig
+			if err := item.Decode(&port); err != nil {
+				return err
+			}
+			if err := validatePortConfig(&port); err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			ports = append(ports, port)
+		default:
+			return fmt.Errorf("line %d: invalid port definition", item.Line)
+		}
+	}
+
+	*l = ports
+	return nil
+}
+
+// ParsePortMapping parse une définition de port en syntaxe courte :
+// [[IP:](HOST|HOST_RANGE):](CONTAINER|CONTAINER_RANGE)[/PROTOCOL]
+// Les adresses IPv6 doivent être entre crochets ("[::1]:80:80").
+func ParsePortMapping(mapping string) ([]PortConfig, error) {
+	spec := strings.TrimSpace(mapping)
+	if spec == "" {
+		return nil, fmt.Errorf("empty port definition")
+	}
+
+	protocol := "tcp"
+	if idx := strings.LastIndex(spec, "/"); idx >= 0 {
+		protocol = strings.ToLower(spec[idx+1:])
+		spec = spec[:idx]
+	}
+	if !isValidProtocol(protocol) {
+		return nil, fmt.Errorf("invalid protocol %q in port %q", protocol, mapping)
+	}
+
+	var hostIP, published, container string
+
+	if strings.HasPrefix(spec, "[") {
+		// Adresse IPv6 : [::1]:8080:80
+		end := strings.Index(spec, "]")
+		if end < 0 || len(spec) <= end+1 || spec[end+1] != ':' {
+			return nil, fmt.Errorf("invalid IPv6 port definition %q", mapping)
+		}
+		hostIP = spec[1:end]
+		parts := strings.Split(spec[end+2:], ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid port definition %q", mapping)
+		}
+		published, container = parts[0], parts[1]
+	} else {
+		parts := strings.Split(spec, ":")
+		switch len(parts) {
+		case 1:
+			container = parts[0]
+		case 2:
+			published, container = parts[0], parts[1]
+		case 3:
+			hostIP, published, container = parts[0], parts[1], parts[2]
+		default:
+			return nil, fmt.Errorf("invalid port definition %q", mapping)
+		}
+	}
+
+	containerStart, containerEnd, err := parsePortRange(container)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container port in %q: %w", mapping, err)
+	}
+
+	var publishedStart, publishedEnd int
+	if published != "" {
+		publishedStart, publishedEnd, err = parsePortRange(published)
+		if err != nil {
+			return nil, fmt.Errorf("invalid published port in %q: %w", mapping, err)
+		}
+	}
+
+	count := containerEnd - containerStart + 1
+	publishedCount := publishedEnd - publishedStart + 1
+
+	var ports []PortConfig
+	for i := 0; i < count; i++ {
+		port := PortConfig{
+			Target:   containerStart + i,
+			HostIP:   hostIP,
+			Protocol: protocol,
+		}
+
+		switch {
+		case published == "":
+			// Port non publié
+		case publishedCount == count:
+			port.Published = strconv.Itoa(publishedStart + i)
+		case count == 1:
+			// Un port conteneur publié sur une plage de ports hôte
+			port.Published = published
+		default:
+			return nil, fmt.Errorf("port ranges don't match in %q", mapping)
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports, nil
+}
+
+// parsePortRange parse un port ("80") ou une plage de ports ("3000-3005")
+func parsePortRange(value string) (int, int, error) {
+	value = strings.TrimSpace(value)
+	startStr, endStr, isRange := strings.Cut(value, "-")
+
+	start, err := parsePortNumber(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err := parsePortNumber(endStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("invalid port range %q", value)
+	}
+
+	return start, end, nil
+}
+
+// parsePortNumber parse et valide un numéro de port
+func parsePortNumber(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port number %q", value)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port number %d out of range", port)
+	}
+	return port, nil
+}
+
+// validatePortConfig valide et normalise un port en syntaxe longue
+func validatePortConfig(port *PortConfig) error {
+	if port.Target < 1 || port.Target > 65535 {
+		return fmt.Errorf("invalid target port %d", port.Target)
+	}
+
+	if port.Published != "" {
+		if _, _, err := parsePortRange(port.Published); err != nil {
+			return fmt.Errorf("invalid published port: %w", err)
+		}
+	}
+
+	port.Protocol = strings.ToLower(port.Protocol)
+	if port.Protocol == "" {
+		port.Protocol = "tcp"
+	}
+	if !isValidProtocol(port.Protocol) {
+		return fmt.Errorf("invalid protocol %q", port.Protocol)
+	}
+
+	if port.Mode != "" && port.Mode != "ingress" && port.Mode != "host" {
+		return fmt.Errorf("invalid port mode %q", port.Mode)
+	}
+
+	return nil
+}
+
+func isValidProtocol(protocol string) bool {
+	return protocol == "tcp" || protocol == "udp" || protocol == "sctp"
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortMapping(t *testing.T) {
+	cases := []struct {
+		mapping  string
+		expected []PortConfig
+	}{
+		{"80", []PortConfig{{Target: 80, Protocol: "tcp"}}},
+		{"8080:80/udp", []PortConfig{{Target: 80, Published: "8080", Protocol: "udp"}}},
+		{"127.0.0.1::80", []PortConfig{{Target: 80, HostIP: "127.0.0.1", Protocol: "tcp"}}},
+		{"[::1]:8080:80", []PortConfig{{Target: 80, Published: "8080", HostIP: "::1", Protocol: "tcp"}}},
+		{"9000-9010:80", []PortConfig{{Target: 80, Published: "9000-9010", Protocol: "tcp"}}},
+		{"3000-3001:4000-4001", []PortConfig{
+			{Target: 4000, Published: "3000", Protocol: "tcp"},
+			{Target: 4001, Published: "3001", Protocol: "tcp"},
+		}},
+	}
+
+	for _, tc := range cases {
+		ports, err := ParsePortMapping(tc.mapping)
+		require.NoError(t, err, tc.mapping)
+		assert.Equal(t, tc.expected, ports, tc.mapping)
+	}
+
+	for _, invalid := range []string{"", "abc", "80/http", "3000-3002:80-81", "70000", "[::1]80"} {
+		_, err := ParsePortMapping(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseLongSyntaxPorts(t *testing.T) {
+	compose, err := ParseDockerCompose(`services:
+  web:
+    image: nginx
+    ports:
+      - "3000-3001:3000-3001"
+      - target: 443
+        published: 8443
+        protocol: TCP
+        name: https
+        app_protocol: https
+        mode: host
+`)
+	require.NoError(t, err)
+
+	ports := compose.Services["web"].Ports
+	require.Len(t, ports, 3)
+	assert.Equal(t, 3001, ports[1].Target)
+	assert.Equal(t, PortConfig{
+		Name:        "https",
+		Target:      443,
+		Published:   "8443",
+		Protocol:    "tcp",
+		AppProtocol: "https",
+		Mode:        "host",
+	}, ports[2])
+	assert.Equal(t, 8443, ports[2].PublishedPort())
+}
@@ -0,0 +1,185 @@
+package docker
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position représente la position d'un élément dans un fichier source
+type Position struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// SourceMap associe un pointeur JSON (ex: /services/web/ports/0) à sa position dans les fichiers source
+type SourceMap map[string]Position
+
+// Lookup retourne la position d'un pointeur JSON, ou celle de son ancêtre le plus proche
+func (m SourceMap) Lookup(pointer string) (Position, bool) {
+	for {
+		if position, ok := m[pointer]; ok {
+			return position, true
+		}
+		if pointer == "" {
+			return Position{}, false
+		}
+		index := strings.LastIndex(pointer, "/")
+		if index < 0 {
+			return Position{}, false
+		}
+		pointer = pointer[:index]
+	}
+}
+
+// Locate complète le fichier, la ligne et la colonne d'un diagnostic à partir de son champ
+func (m SourceMap) Locate(d *Diagnostic) {
+	if d.Line > 0 || d.Field == "" {
+		return
+	}
+	if position, ok := m.Lookup(d.Field); ok {
+		d.Line = position.Line
+		d.Column = position.Column
+		if d.File == "" {
+			d.File = position.File
+		}
+	}
+}
+
+// pointerAtLine retourne le pointeur le plus précis déclaré à une ligne donnée
+func (m SourceMap) pointerAtLine(line int) (string, bool) {
+	best, found := "", false
+	for pointer, position := range m {
+		if position.Line != line {
+			continue
+		}
+		if !found || len(pointer) > len(best) || (len(pointer) == len(best) && pointer < best) {
+			best, found = pointer, true
+		}
+	}
+	return best, found
+}
+
+// FieldPointer construit le pointeur JSON d'un champ à partir de ses segments
+func FieldPointer(segments ...string) string {
+	return jsonPointer(segments)
+}
+
+// ServiceField construit le pointeur JSON d'un champ de service (ex: /services/web/ports)
+func ServiceField(service string, keys ...string) string {
+	return jsonPointer(append([]string{"services", service}, keys...))
+}
+
+// buildSourceMap indexe la position de chaque clé et élément de liste d'un arbre YAML.
+// origins associe les nœuds à leur fichier d'origine ; un nœud sans origine hérite du fichier de son parent.
+func buildSourceMap(root *yaml.Node, origins map[*yaml.Node]string) SourceMap {
+	sourceMap := make(SourceMap)
+	if root == nil {
+		return sourceMap
+	}
+
+	var walk func(node *yaml.Node, path []string, file string)
+	walk = func(node *yaml.Node, path []string, file string) {
+		if origin, ok := origins[node]; ok {
+			file = origin
+		}
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				keyFile := file
+				if origin, ok := origins[key]; ok {
+					keyFile = origin
+				}
+				childPath := appendPath(path, key.Value)
+				sourceMap[jsonPointer(childPath)] = Position{File: keyFile, Line: key.Line, Column: key.Column}
+				walk(value, childPath, keyFile)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				itemFile := file
+				if origin, ok := origins[item]; ok {
+					itemFile = origin
+				}
+				childPath := appendPath(path, strconv.Itoa(i))
+				sourceMap[jsonPointer(childPath)] = Position{File: itemFile, Line: item.Line, Column: item.Column}
+				walk(item, childPath, itemFile)
+			}
+		}
+	}
+
+	sourceMap[""] = Position{File: origins[root], Line: root.Line, Column: root.Column}
+	walk(root, nil, origins[root])
+	return sourceMap
+}
+
+// recordOrigins associe chaque nœud d'un document à son fichier
+func recordOrigins(node *yaml.Node, file string, origins map[*yaml.Node]string) {
+	origins[node] = file
+	for _, child := range node.Content {
+		recordOrigins(child, file, origins)
+	}
+}
+
+// linePrefix reconnaît le préfixe "line N: " des erreurs produites par yaml.v3 et les décodeurs du package
+var linePrefix = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// decodeErrors convertit une erreur de décodage en diagnostics localisés
+func decodeErrors(err error, sourceMap SourceMap) ParseErrors {
+	var messages []string
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	diagnostics := make(ParseErrors, 0, len(messages))
+	for _, message := range messages {
+		diagnostic := Diagnostic{Code: "INVALID_FIELD", Message: message}
+
+		if match := linePrefix.FindStringSubmatch(message); match != nil {
+			diagnostic.Line, _ = strconv.Atoi(match[1])
+			diagnostic.Message = message[len(match[0]):]
+
+			if pointer, ok := sourceMap.pointerAtLine(diagnostic.Line); ok {
+				position := sourceMap[pointer]
+				diagnostic.Field = pointer
+				diagnostic.File = position.File
+				diagnostic.Column = position.Column
+				diagnostic.Service = serviceFromPath(splitPointer(pointer))
+			}
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// syntaxError convertit une erreur de syntaxe YAML en diagnostic localisé
+func syntaxError(file string, err error) Diagnostic {
+	diagnostic := Diagnostic{Code: "YAML_SYNTAX_ERROR", File: file, Message: err.Error()}
+	if match := linePrefix.FindStringSubmatch(err.Error()); match != nil {
+		diagnostic.Line, _ = strconv.Atoi(match[1])
+		diagnostic.Message = err.Error()[len(match[0]):]
+	}
+	return diagnostic
+}
+
+// splitPointer découpe un pointeur JSON en segments
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segments[i] = strings.ReplaceAll(segment, "~0", "~")
+	}
+	return segments
+}
@@ -0,0 +1,46 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceMapPositions(t *testing.T) {
+	compose, _, err := ParseDockerComposeFiles([]ComposeFile{
+		{Name: "docker-compose.yml", Content: "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:80\"\n"},
+		{Name: "docker-compose.prod.yml", Content: "services:\n  web:\n    ipc: host\n"},
+	}, ParseOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, Position{File: "docker-compose.yml", Line: 5, Column: 9}, compose.Positions["/services/web/ports/0"])
+	assert.Equal(t, Position{File: "docker-compose.prod.yml", Line: 3, Column: 5}, compose.Positions["/services/web/ipc"])
+
+	// Un champ absent est localisé sur son ancêtre le plus proche
+	position, ok := compose.Positions.Lookup("/services/web/healthcheck/test")
+	require.True(t, ok)
+	assert.Equal(t, 2, position.Line)
+}
+
+func TestDecodeErrorsAreLocated(t *testing.T) {
+	_, _, err := ParseDockerComposeFiles([]ComposeFile{
+		{Name: "docker-compose.yml", Content: "services:\n  web:\n    image: nginx\n    ports:\n      - \"80:http\"\n"},
+	}, ParseOptions{})
+
+	var parseErrors ParseErrors
+	require.ErrorAs(t, err, &parseErrors)
+	require.Len(t, parseErrors, 1)
+	assert.Equal(t, "docker-compose.yml", parseErrors[0].File)
+	assert.Equal(t, 5, parseErrors[0].Line)
+	assert.Equal(t, 9, parseErrors[0].Column)
+	assert.Equal(t, "/services/web/ports/0", parseErrors[0].Field)
+	assert.Equal(t, "web", parseErrors[0].Service)
+
+	_, _, err = ParseDockerComposeFiles([]ComposeFile{
+		{Name: "docker-compose.yml", Content: "services:\n  web:\n    image: nginx\n   bad: [\n"},
+	}, ParseOptions{})
+	require.ErrorAs(t, err, &parseErrors)
+	assert.Equal(t, "YAML_SYNTAX_ERROR", parseErrors[0].Code)
+	assert.NotZero(t, parseErrors[0].Line)
+}
@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AllProfiles active tous les profils
+const AllProfiles = "*"
+
+// ServiceSelection décrit les services à conserver pour la conversion
+type ServiceSelection struct {
+	Profiles []string // Profils actifs ; les services sans profil sont toujours actifs
+	Services []string // Liste explicite de services ; vide = tous les services actifs
+}
+
+// SelectServices filtre les services du compose selon les profils actifs et la liste explicite,
+// en incluant transitivement les dépendances (depends_on) des services sélectionnés.
+// Les services retirés sont retournés triés.
+func SelectServices(compose *DockerCompose, selection ServiceSelection) ([]string, []Diagnostic, error) {
+	if compose == nil || len(compose.Services) == 0 {
+		return nil, nil, nil
+	}
+
+	var errs ParseErrors
+	var warnings []Diagnostic
+	selected := make(map[string]bool)
+
+	if len(selection.Services) > 0 {
+		// Un service demandé explicitement est actif quel que soit son profil
+		for _, name := range selection.Services {
+			if _, ok := compose.Services[name]; !ok {
+				errs = append(errs, Diagnostic{
+					Code:    "UNKNOWN_SERVICE",
+					Message: fmt.Sprintf("service %s selected for conversion is not defined", name),
+					Service: name,
+				})
+				continue
+			}
+			selected[name] = true
+		}
+	} else {
+		for name, service := range compose.Services {
+			if isServiceEnabled(service, selection.Profiles) {
+				selected[name] = true
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	// Inclure transitivement les dépendances des services sélectionnés
+	queue := sortedKeys(selected)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		dependencies, _ := compose.Services[name].DependsOn.(map[string]DependencyConfig)
+		for _, dependency := range sortedKeys(dependencies) {
+			if _, ok := compose.Services[dependency]; !ok {
+				warnings = append(warnings, Diagnostic{
+					Code:    "UNKNOWN_DEPENDENCY",
+					Message: fmt.Sprintf("service %s depends on undefined service %s", name, dependency),
+					Service: name,
+					Field:   ServiceField(name, "depends_on", dependency),
+				})
+				compose.Positions.Locate(&warnings[len(warnings)-1])
+				continue
+			}
+			if !selected[dependency] {
+				selected[dependency] = true
+				queue = append(queue, dependency)
+			}
+		}
+	}
+
+	var excluded []string
+	for name := range compose.Services {
+		if !selected[name] {
+			excluded = append(excluded, name)
+			delete(compose.Services, name)
+		}
+	}
+	sort.Strings(excluded)
+
+	return excluded, warnings, nil
+}
+
+// isServiceEnabled indique si un service est actif pour les profils donnés
+func isServiceEnabled(service Service, profiles []string) bool {
+	if len(service.Profiles) == 0 {
+		return true
+	}
+
+	for _, active := range profiles {
+		if active == AllProfiles {
+			return true
+		}
+		for _, profile := range service.Profiles {
+			if profile == active {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedKeys retourne les clés d'une map triées
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
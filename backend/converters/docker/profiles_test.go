@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesCompose = `services:
+  web:
+    image: nginx
+    depends_on:
+      - api
+  api:
+    image: api
+    depends_on:
+      db:
+        condition: service_healthy
+  db:
+    image: postgres
+  adminer:
+    image: adminer
+    profiles: [dev]
+  mailhog:
+    image: mailhog/mailhog
+    profiles: [dev, mail]
+`
+
+func TestSelectServices(t *testing.T) {
+	cases := []struct {
+		name      string
+		selection ServiceSelection
+		expected  []string
+	}{
+		{"no profile", ServiceSelection{}, []string{"adminer", "mailhog"}},
+		{"mail profile", ServiceSelection{Profiles: []string{"mail"}}, []string{"adminer"}},
+		{"all profiles", ServiceSelection{Profiles: []string{AllProfiles}}, nil},
+		{"allow-list with dependencies", ServiceSelection{Services: []string{"api"}}, []string{"adminer", "mailhog", "web"}},
+		{"explicit profiled service", ServiceSelection{Services: []string{"adminer"}}, []string{"api", "db", "mailhog", "web"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			compose, err := ParseDockerCompose(profilesCompose)
+			require.NoError(t, err)
+
+			excluded, warnings, err := SelectServices(compose, tc.selection)
+			require.NoError(t, err)
+			assert.Empty(t, warnings)
+			assert.Equal(t, tc.expected, excluded)
+			for _, name := range excluded {
+				assert.NotContains(t, compose.Services, name)
+			}
+		})
+	}
+
+	compose, err := ParseDockerCompose(profilesCompose)
+	require.NoError(t, err)
+	_, _, err = SelectServices(compose, ServiceSelection{Services: []string{"missing"}})
+	assert.Error(t, err)
+}
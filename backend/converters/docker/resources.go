@@ -0,0 +1,195 @@
+package docker
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Répertoire par défaut des secrets montés dans un conteneur
+const defaultSecretsDir = "/run/secrets"
+
+// FileReference référence d'un service vers un secret ou une config de premier niveau
+type FileReference struct {
+	Source string
+	Target string
+	UID    string
+	GID    string
+	Mode   *uint32
+
+	// Name nom de la ressource sur la plateforme, résolu par le parser
+	Name string
+}
+
+// FileReferenceList représente les références secrets ou configs d'un service
+type FileReferenceList []FileReference
+
+// UnmarshalYAML décode les syntaxes courte (nom) et longue (source, target, uid, gid, mode)
+func (l *FileReferenceList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: expected a list of references", value.Line)
+	}
+
+	references := make(FileReferenceList, 0, len(value.Content))
+	for _, item := range value.Content {
+		if item.Kind == yaml.ScalarNode {
+			references = append(references, FileReference{Source: item.Value})
+			continue
+		}
+
+		var long struct {
+			Source string    `yaml:"source"`
+			Target string    `yaml:"target"`
+			UID    string    `yaml:"uid"`
+			GID    string    `yaml:"gid"`
+			Mode   yaml.Node `yaml:"mode"`
+		}
+		if err := item.Decode(&long); err != nil {
+			return err
+		}
+
+		reference := FileReference{Source: long.Source, Target: long.Target, UID: long.UID, GID: long.GID}
+		if long.Mode.Kind == yaml.ScalarNode {
+			mode, err := parseFileMode(long.Mode)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid mode %q: %w", long.Mode.Line, long.Mode.Value, err)
+			}
+			reference.Mode = &mode
+		}
+		references = append(references, reference)
+	}
+
+	*l = references
+	return nil
+}
+
+// parseFileMode lit un mode de fichier : entier YAML (0440, 0o440) ou chaîne octale ("0440")
+func parseFileMode(node yaml.Node) (uint32, error) {
+	value := strings.TrimSpace(node.Value)
+	base := 0
+	if node.Tag == "!!str" {
+		base = 8
+		value = strings.TrimPrefix(strings.TrimPrefix(value, "0o"), "0O")
+	} else if strings.HasPrefix(value, "0") && !strings.HasPrefix(value, "0x") && !strings.HasPrefix(value, "0o") {
+		// YAML 1.1 : un entier préfixé par 0 est octal
+		base = 8
+	}
+	mode, err := strconv.ParseUint(value, base, 32)
+	if err != nil {
+		return 0, err
+	}
+	if mode > 0777 {
+		return 0, fmt.Errorf("mode must be between 0 and 0777")
+	}
+	return uint32(mode), nil
+}
+
+// IsExternal indique si un secret, une config ou un volume est externe (external: true ou external.name)
+func IsExternal(external interface{}) bool {
+	switch e := external.(type) {
+	case bool:
+		return e
+	case map[string]interface{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResourceName retourne le nom d'un secret ou d'une config sur la plateforme (nom externe, name ou clé)
+func ResourceName(key, name string, external interface{}) string {
+	if e, ok := external.(map[string]interface{}); ok {
+		if externalName, ok := e["name"].(string); ok && externalName != "" {
+			return externalName
+		}
+	}
+	if name != "" {
+		return name
+	}
+	return key
+}
+
+// normalizeFileReferences vérifie que les secrets et configs référencés existent
+// et complète la cible par défaut (/run/secrets/<source> pour un secret, /<source> pour une config)
+func normalizeFileReferences(compose *DockerCompose, serviceName string, service *Service) ParseErrors {
+	var errs ParseErrors
+
+	for i := range service.Secrets {
+		reference := &service.Secrets[i]
+		if secret, ok := compose.Secrets[reference.Source]; ok {
+			reference.Name = ResourceName(reference.Source, secret.Name, secret.External)
+		} else {
+			errs = append(errs, undefinedReference(serviceName, "secrets", i, reference.Source))
+		}
+		switch {
+		case reference.Target == "":
+			reference.Target = path.Join(defaultSecretsDir, reference.Source)
+		case !path.IsAbs(reference.Target):
+			reference.Target = path.Join(defaultSecretsDir, reference.Target)
+		}
+	}
+
+	for i := range service.Configs {
+		reference := &service.Configs[i]
+		if config, ok := compose.Configs[reference.Source]; ok {
+			reference.Name = ResourceName(reference.Source, config.Name, config.External)
+		} else {
+			errs = append(errs, undefinedReference(serviceName, "configs", i, reference.Source))
+		}
+		switch {
+		case reference.Target == "":
+			reference.Target = "/" + reference.Source
+		case !path.IsAbs(reference.Target):
+			reference.Target = "/" + reference.Target
+		}
+	}
+
+	return errs
+}
+
+// undefinedReference signale une référence vers un secret ou une config non déclaré
+func undefinedReference(serviceName, kind string, index int, source string) Diagnostic {
+	code := "UNDEFINED_SECRET"
+	if kind == "configs" {
+		code = "UNDEFINED_CONFIG"
+	}
+	return Diagnostic{
+		Code:    code,
+		Message: fmt.Sprintf("service %s refers to undefined %s %s", serviceName, strings.TrimSuffix(kind, "s"), source),
+		Service: serviceName,
+		Field:   ServiceField(serviceName, kind, strconv.Itoa(index)),
+	}
+}
+
+// resolveResourceData résout le contenu des secrets et configs : contenu en ligne,
+// fichier fourni avec la requête (relatif au fichier principal) ou variable d'environnement
+func resolveResourceData(compose *DockerCompose, files map[string]string, dir string, environment map[string]string) {
+	resolve := func(file, variable string) (string, bool) {
+		if file != "" {
+			data, ok := files[resolveFilePath(dir, file)]
+			return data, ok
+		}
+		if variable != "" {
+			data, ok := environment[variable]
+			return data, ok
+		}
+		return "", false
+	}
+
+	for name, secret := range compose.Secrets {
+		secret.Data, secret.Resolved = resolve(secret.File, secret.Environment)
+		compose.Secrets[name] = secret
+	}
+
+	for name, config := range compose.Configs {
+		if config.Content != "" {
+			config.Data, config.Resolved = config.Content, true
+		} else {
+			config.Data, config.Resolved = resolve(config.File, config.Environment)
+		}
+		compose.Configs[name] = config
+	}
+}
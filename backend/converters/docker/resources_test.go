@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretAndConfigReferences(t *testing.T) {
+	compose, _, err := ParseDockerComposeFiles([]ComposeFile{{Name: "deploy/compose.yaml", Content: `services:
+  api:
+    image: api
+    secrets:
+      - db_password
+      - source: tls_key
+        target: server.key
+        uid: "1000"
+        mode: 0400
+    configs:
+      - nginx
+      - source: app
+        target: /etc/app/config.yaml
+        mode: "0644"
+secrets:
+  db_password:
+    file: ./secrets/db_password.txt
+  tls_key:
+    environment: TLS_KEY
+  registry:
+    external: true
+configs:
+  nginx:
+    file: ./nginx.conf
+  app:
+    content: |
+      debug: false
+`}}, ParseOptions{
+		Environment: map[string]string{"TLS_KEY": "-----BEGIN KEY-----"},
+		Files:       map[string]string{"deploy/secrets/db_password.txt": "hunter2"},
+	})
+	require.NoError(t, err)
+
+	api := compose.Services["api"]
+	require.Len(t, api.Secrets, 2)
+	assert.Equal(t, FileReference{Source: "db_password", Target: "/run/secrets/db_password", Name: "db_password"}, api.Secrets[0])
+	assert.Equal(t, "/run/secrets/server.key", api.Secrets[1].Target)
+	assert.Equal(t, "1000", api.Secrets[1].UID)
+	require.NotNil(t, api.Secrets[1].Mode)
+	assert.Equal(t, uint32(0400), *api.Secrets[1].Mode)
+
+	require.Len(t, api.Configs, 2)
+	assert.Equal(t, "/nginx", api.Configs[0].Target)
+	assert.Nil(t, api.Configs[0].Mode)
+	assert.Equal(t, uint32(0644), *api.Configs[1].Mode)
+
+	// Contenu résolu depuis le bundle, l'environnement ou la valeur en ligne
+	assert.True(t, compose.Secrets["db_password"].Resolved)
+	assert.Equal(t, "hunter2", compose.Secrets["db_password"].Data)
+	assert.Equal(t, "-----BEGIN KEY-----", compose.Secrets["tls_key"].Data)
+	assert.Equal(t, "debug: false\n", compose.Configs["app"].Data)
+	assert.False(t, compose.Configs["nginx"].Resolved)
+	assert.True(t, IsExternal(compose.Secrets["registry"].External))
+}
+
+func TestUndefinedSecretReference(t *testing.T) {
+	_, _, err := ParseDockerComposeFiles([]ComposeFile{{Content: `services:
+  api:
+    image: api
+    secrets: [missing]
+`}}, ParseOptions{})
+	require.Error(t, err)
+
+	var diagnostics ParseErrors
+	require.True(t, errors.As(err, &diagnostics))
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "UNDEFINED_SECRET", diagnostics[0].Code)
+	assert.Equal(t, "/services/api/secrets/0", diagnostics[0].Field)
+	assert.Equal(t, 4, diagnostics[0].Line)
+}
@@ -0,0 +1,309 @@
+package docker
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// composeSchema schéma JSON officiel de la spécification Compose
+//
+//go:embed schema/compose-spec.json
+var composeSchema string
+
+var (
+	compiledSchema     *jsonschema.Schema
+	compiledSchemaErr  error
+	compiledSchemaOnce sync.Once
+)
+
+// compileSchema compile le schéma Compose une seule fois
+func compileSchema() (*jsonschema.Schema, error) {
+	compiledSchemaOnce.Do(func() {
+		document, err := jsonschema.UnmarshalJSON(strings.NewReader(composeSchema))
+		if err != nil {
+			compiledSchemaErr = err
+			return
+		}
+
+		compiler := jsonschema.NewCompiler()
+		compiler.AssertFormat()
+		if err := compiler.AddResource("compose-spec.json", document); err != nil {
+			compiledSchemaErr = err
+			return
+		}
+		compiler.RegisterFormat(&jsonschema.Format{
+			Name: "duration",
+			Validate: func(value any) error {
+				duration, ok := value.(string)
+				if !ok {
+					return nil
+				}
+				_, err := time.ParseDuration(duration)
+				return err
+			},
+		})
+		compiledSchema, compiledSchemaErr = compiler.Compile("compose-spec.json")
+	})
+	return compiledSchema, compiledSchemaErr
+}
+
+// validateSchema valide un modèle docker-compose contre le schéma Compose
+// et retourne chaque violation sous forme de diagnostic localisé
+func validateSchema(model *yaml.Node, sourceMap SourceMap) (ParseErrors, error) {
+	schema, err := compileSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile compose schema: %w", err)
+	}
+
+	instance, err := schemaInstance(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var validationError *jsonschema.ValidationError
+	if err := schema.Validate(instance); !errors.As(err, &validationError) {
+		return nil, err
+	}
+
+	violations := schemaViolations(validationError)
+
+	// unevaluatedProperties rejette aussi les propriétés dont la valeur est invalide :
+	// ce rejet n'est conservé que pour les propriétés inconnues
+	invalid := make(map[string]bool)
+	for _, violation := range violations {
+		if _, ok := violation.ErrorKind.(*kind.FalseSchema); !ok {
+			invalid[jsonPointer(violation.InstanceLocation)] = true
+		}
+	}
+
+	printer := message.NewPrinter(language.English)
+	seen := make(map[string]bool)
+	var diagnostics ParseErrors
+	for _, leaf := range violations {
+		if _, ok := leaf.ErrorKind.(*kind.FalseSchema); ok && invalid[jsonPointer(leaf.InstanceLocation)] {
+			continue
+		}
+
+		diagnostic := schemaDiagnostic(leaf, printer)
+		key := diagnostic.Field + "\x00" + diagnostic.Message
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		sourceMap.Locate(&diagnostic)
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Field < diagnostics[j].Field
+	})
+	return diagnostics, nil
+}
+
+// schemaInstance convertit un nœud YAML en valeur JSON attendue par le validateur
+func schemaInstance(model *yaml.Node) (any, error) {
+	if model == nil {
+		return map[string]any{}, nil
+	}
+
+	var value any
+	if err := model.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	// Passer par JSON pour obtenir des types compatibles (map[string]any, json.Number)
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+}
+
+// schemaViolations retourne les violations les plus précises de chaque branche d'erreur
+func schemaViolations(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	// Pour oneOf/anyOf, seule l'alternative la plus proche de la valeur est significative
+	switch err.ErrorKind.(type) {
+	case *kind.OneOf, *kind.AnyOf:
+		return []*jsonschema.ValidationError{mostSpecificViolation(err)}
+	}
+
+	var violations []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		violations = append(violations, schemaViolations(cause)...)
+	}
+	return violations
+}
+
+// mostSpecificViolation retourne la violation la plus profonde d'une arborescence d'erreurs
+func mostSpecificViolation(err *jsonschema.ValidationError) *jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return err
+	}
+
+	var best *jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		cause = mostSpecificViolation(cause)
+		if best == nil || violationSpecificity(cause) > violationSpecificity(best) {
+			best = cause
+		}
+	}
+	return best
+}
+
+func violationSpecificity(err *jsonschema.ValidationError) int {
+	if _, ok := err.ErrorKind.(*kind.AdditionalProperties); ok {
+		return len(err.InstanceLocation) + 1
+	}
+	return len(err.InstanceLocation)
+}
+
+// schemaDiagnostic convertit une violation du schéma en diagnostic
+func schemaDiagnostic(err *jsonschema.ValidationError, printer *message.Printer) Diagnostic {
+	path := err.InstanceLocation
+	diagnostic := Diagnostic{
+		Code:    "SCHEMA_VIOLATION",
+		Service: serviceFromPath(path),
+	}
+
+	var detail string
+	switch k := err.ErrorKind.(type) {
+	case *kind.AdditionalProperties:
+		// Pointer directement sur la propriété inconnue (ex: "enviroment")
+		if len(k.Properties) > 0 {
+			path = appendPath(path, k.Properties[0])
+		}
+		detail = fmt.Sprintf("additional property %s is not allowed", strings.Join(k.Properties, ", "))
+	case *kind.FalseSchema:
+		// Propriété non autorisée par unevaluatedProperties: false
+		if len(path) > 0 {
+			parent := strings.Join(path[:len(path)-1], ".")
+			diagnostic.Field = jsonPointer(path)
+			diagnostic.Message = fmt.Sprintf("additional property %s is not allowed", path[len(path)-1])
+			if parent != "" {
+				diagnostic.Message = fmt.Sprintf("%s %s", parent, diagnostic.Message)
+			}
+			if len(path) == 3 && path[0] == "services" {
+				if candidate := closestServiceKey(path[2]); candidate != "" {
+					diagnostic.Suggestion = fmt.Sprintf("Did you mean %q?", candidate)
+				}
+			}
+			return diagnostic
+		}
+		detail = "is not allowed"
+	case *kind.Type:
+		detail = fmt.Sprintf("must be a %s", humanReadableType(k.Want))
+	default:
+		detail = err.ErrorKind.LocalizedString(printer)
+	}
+
+	diagnostic.Field = jsonPointer(path)
+	location := strings.Join(err.InstanceLocation, ".")
+	if location == "" {
+		diagnostic.Message = detail
+	} else {
+		diagnostic.Message = fmt.Sprintf("%s %s", location, detail)
+	}
+	return diagnostic
+}
+
+// humanReadableType décrit les types JSON attendus avec le vocabulaire YAML
+func humanReadableType(want []string) string {
+	names := make([]string, len(want))
+	for i, name := range want {
+		if name == "object" {
+			name = "mapping"
+		}
+		names[i] = name
+	}
+	sort.Strings(names)
+
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+var (
+	serviceKeys     []string
+	serviceKeysOnce sync.Once
+)
+
+// closestServiceKey retourne la clé de service connue la plus proche d'une clé inconnue (faute de frappe)
+func closestServiceKey(key string) string {
+	serviceKeysOnce.Do(func() {
+		var document struct {
+			Defs map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+				AllOf      []struct {
+					Ref string `json:"$ref"`
+				} `json:"allOf"`
+			} `json:"$defs"`
+		}
+		if err := json.Unmarshal([]byte(composeSchema), &document); err != nil {
+			return
+		}
+
+		// Les propriétés d'un service sont réparties entre sa définition et ses allOf
+		definitions := []string{"service"}
+		for _, ref := range document.Defs["service"].AllOf {
+			definitions = append(definitions, strings.TrimPrefix(ref.Ref, "#/$defs/"))
+		}
+		for _, definition := range definitions {
+			for name := range document.Defs[definition].Properties {
+				serviceKeys = append(serviceKeys, name)
+			}
+		}
+		sort.Strings(serviceKeys)
+	})
+
+	best, bestDistance := "", 3 // au plus deux modifications
+	for _, candidate := range serviceKeys {
+		if distance := editDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance calcule la distance de Levenshtein entre deux chaînes
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
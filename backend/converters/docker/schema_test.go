@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaValidationReportsEveryViolation(t *testing.T) {
+	_, _, err := ParseDockerComposeFiles([]ComposeFile{{Name: "docker-compose.yml", Content: `services:
+  web:
+    image: nginx
+    enviroment:
+      DEBUG: "true"
+    port:
+      - "80:80"
+  db:
+    image: 3
+`}}, ParseOptions{})
+
+	var parseErrors ParseErrors
+	require.ErrorAs(t, err, &parseErrors)
+	require.Len(t, parseErrors, 3)
+
+	assert.Equal(t, "SCHEMA_VIOLATION", parseErrors[0].Code)
+	assert.Equal(t, "/services/web/enviroment", parseErrors[0].Field)
+	assert.Equal(t, 4, parseErrors[0].Line)
+	assert.Equal(t, `Did you mean "environment"?`, parseErrors[0].Suggestion)
+
+	assert.Equal(t, "/services/web/port", parseErrors[1].Field)
+	assert.Equal(t, `Did you mean "ports"?`, parseErrors[1].Suggestion)
+
+	assert.Equal(t, "/services/db/image", parseErrors[2].Field)
+	assert.Equal(t, "db", parseErrors[2].Service)
+	assert.Contains(t, parseErrors[2].Message, "must be a string")
+}
+
+func TestSchemaValidationAcceptsExtensions(t *testing.T) {
+	_, _, err := ParseDockerComposeFiles([]ComposeFile{{Content: `x-common: &common
+  restart: always
+services:
+  web:
+    <<: *common
+    image: nginx
+    x-team: frontend
+`}}, ParseOptions{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrShellSyntax signale une commande qui ne peut s'exécuter qu'à travers un shell
+var ErrShellSyntax = errors.New("command requires a shell")
+
+// shellSyntax caractères qui, hors guillemets, n'ont de sens que pour un shell
+const shellSyntax = ";&|<>()$`*?[]{}~#"
+
+// SplitShellWords découpe une commande en arguments selon les règles de découpage d'un shell POSIX,
+// comme docker-compose pour les commandes et entrypoints écrits sous forme de chaîne :
+//   - les espaces séparent les arguments ;
+//   - les guillemets simples protègent leur contenu tel quel ;
+//   - les guillemets doubles protègent leur contenu, où \ n'échappe que ", \, $, ` et le saut de ligne ;
+//   - hors guillemets, \ échappe le caractère suivant.
+//
+// Aucune expansion n'est effectuée : variables, opérateurs et jokers restent des caractères ordinaires.
+func SplitShellWords(command string) ([]string, error) {
+	return splitShellWords(command, false)
+}
+
+// SplitSimpleCommand découpe comme SplitShellWords une commande qui peut s'exécuter sans shell.
+// Elle échoue avec ErrShellSyntax si la commande utilise des opérateurs, redirections, substitutions,
+// variables ou jokers, dont le sens dépend du shell.
+func SplitSimpleCommand(command string) ([]string, error) {
+	return splitShellWords(command, true)
+}
+
+func splitShellWords(command string, simple bool) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case simple && (r == '$' || r == '`'):
+				return nil, fmt.Errorf("%w: %q", ErrShellSyntax, command)
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]):
+				i++
+				if runes[i] != '\n' {
+					word.WriteRune(runes[i])
+				}
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated escape at the end of %q", command)
+			}
+			i++
+			// Un saut de ligne échappé continue la ligne
+			if runes[i] != '\n' {
+				word.WriteRune(runes[i])
+				inWord = true
+			}
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case simple && strings.ContainsRune(shellSyntax, r):
+			return nil, fmt.Errorf("%w: %q", ErrShellSyntax, command)
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, command)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
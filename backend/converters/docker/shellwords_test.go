@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitShellWords(t *testing.T) {
+	cases := []struct {
+		command  string
+		expected []string
+	}{
+		{"", []string{}},
+		{"  nginx   -g  'daemon off;'  ", []string{"nginx", "-g", "daemon off;"}},
+		{`echo "a \"quoted\" \$word" 'it''s'`, []string{"echo", `a "quoted" $word`, "its"}},
+		{`printf '%s\n' "\d"`, []string{"printf", `%s\n`, `\d`}},
+		{`touch my\ file "" x`, []string{"touch", "my file", "", "x"}},
+		{"run \\\n  --fast", []string{"run", "--fast"}},
+		{"echo $HOME && ls | wc -l", []string{"echo", "$HOME", "&&", "ls", "|", "wc", "-l"}},
+	}
+
+	for _, tc := range cases {
+		words, err := SplitShellWords(tc.command)
+		require.NoError(t, err, tc.command)
+		assert.Equal(t, tc.expected, words, tc.command)
+	}
+
+	for _, command := range []string{`echo "unterminated`, "echo 'unterminated", `trailing\`} {
+		_, err := SplitShellWords(command)
+		assert.Error(t, err, command)
+	}
+}
+
+func TestSplitSimpleCommand(t *testing.T) {
+	words, err := SplitSimpleCommand(`curl -fsS -H 'X-Probe: $1' "http://localhost/health?a=\$b"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"curl", "-fsS", "-H", "X-Probe: $1", "http://localhost/health?a=$b"}, words)
+
+	for _, command := range []string{"pg_isready -U $POSTGRES_USER", "echo ok && true", `echo "$(id)"`, "ls *.log", "cat < file"} {
+		_, err := SplitSimpleCommand(command)
+		assert.ErrorIs(t, err, ErrShellSyntax, command)
+	}
+
+	_, err = SplitSimpleCommand(`echo "unterminated`)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrShellSyntax)
+}
+
+func TestParseCommandReset(t *testing.T) {
+	compose, err := ParseDockerCompose(`services:
+  app:
+    image: app
+    entrypoint: ""
+    command: 'sh -c "echo ready"'
+  worker:
+    image: worker
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{}, compose.Services["app"].Entrypoint)
+	assert.Equal(t, []string{"sh", "-c", "echo ready"}, compose.Services["app"].Command)
+	assert.Nil(t, compose.Services["worker"].Entrypoint)
+	assert.Nil(t, compose.Services["worker"].Command)
+}
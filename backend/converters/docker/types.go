@@ -10,6 +10,9 @@ type DockerCompose struct {
 	Networks map[string]Network        `yaml:"networks,omitempty"`
 	Configs  map[string]Config         `yaml:"configs,omitempty"`
 	Secrets  map[string]Secret         `yaml:"secrets,omitempty"`
+
+	// Positions index des positions source (pointeur JSON -> ligne/colonne), rempli par le parser
+	Positions SourceMap `yaml:"-"`
 }
 
 // Service représente un service dans docker-compose
@@ -17,19 +20,20 @@ type Service struct {
 	Image         string                 `yaml:"image,omitempty"`
 	Build         interface{}            `yaml:"build,omitempty"` // string ou BuildConfig
 	ContainerName string                 `yaml:"container_name,omitempty"`
-	Ports         []string               `yaml:"ports,omitempty"`
+	Ports         PortList               `yaml:"ports,omitempty"`
 	Expose        []string               `yaml:"expose,omitempty"`
 	Environment   interface{}            `yaml:"environment,omitempty"` // []string ou map[string]string
 	EnvFile       interface{}            `yaml:"env_file,omitempty"`    // string ou []string
-	Volumes       []string               `yaml:"volumes,omitempty"`
+	Volumes       VolumeList             `yaml:"volumes,omitempty"`
 	Networks      interface{}            `yaml:"networks,omitempty"` // []string ou map[string]NetworkConfig
 	DependsOn     interface{}            `yaml:"depends_on,omitempty"` // []string ou map[string]DependencyConfig
+	Links         []string               `yaml:"links,omitempty"`      // "service" ou "service:alias"
 	Command       interface{}            `yaml:"command,omitempty"`    // string ou []string
 	Entrypoint    interface{}            `yaml:"entrypoint,omitempty"` // string ou []string
 	WorkingDir    string                 `yaml:"working_dir,omitempty"`
 	User          string                 `yaml:"user,omitempty"`
 	Restart       string                 `yaml:"restart,omitempty"`
-	Labels        map[string]string      `yaml:"labels,omitempty"`
+	Labels        LabelMap               `yaml:"labels,omitempty"`
 	HealthCheck   *HealthCheck           `yaml:"healthcheck,omitempty"`
 	Deploy        *DeployConfig          `yaml:"deploy,omitempty"`
 	Resources     *ResourcesConfig       `yaml:"resources,omitempty"`
@@ -43,6 +47,20 @@ type Service struct {
 	ShmSize       string                 `yaml:"shm_size,omitempty"`
 	PidMode       string                 `yaml:"pid,omitempty"`
 	IpcMode       string                 `yaml:"ipc,omitempty"`
+	Profiles      []string               `yaml:"profiles,omitempty"`
+	CapAdd        []string               `yaml:"cap_add,omitempty"`
+	CapDrop       []string               `yaml:"cap_drop,omitempty"`
+	SecurityOpt   []string               `yaml:"security_opt,omitempty"`
+	Sysctls       interface{}            `yaml:"sysctls,omitempty"` // []string ou map[string]string
+	GroupAdd      []string               `yaml:"group_add,omitempty"`
+	UsernsMode    string                 `yaml:"userns_mode,omitempty"`
+	ExtraHosts    ExtraHostList          `yaml:"extra_hosts,omitempty"`
+	DNS           StringList             `yaml:"dns,omitempty"`
+	DNSSearch     StringList             `yaml:"dns_search,omitempty"`
+	DNSOpt        []string               `yaml:"dns_opt,omitempty"`
+	Devices       DeviceList             `yaml:"devices,omitempty"`
+	Secrets       FileReferenceList      `yaml:"secrets,omitempty"`
+	Configs       FileReferenceList      `yaml:"configs,omitempty"`
 }
 
 // BuildConfig représente la configuration de build
@@ -70,12 +88,13 @@ type DependencyConfig struct {
 
 // HealthCheck représente la configuration de health check
 type HealthCheck struct {
-	Test        interface{}   `yaml:"test,omitempty"`        // string ou []string
-	Interval    time.Duration `yaml:"interval,omitempty"`
-	Timeout     time.Duration `yaml:"timeout,omitempty"`
-	Retries     int           `yaml:"retries,omitempty"`
-	StartPeriod time.Duration `yaml:"start_period,omitempty"`
-	Disable     bool          `yaml:"disable,omitempty"`
+	Test          interface{}   `yaml:"test,omitempty"` // string ou []string
+	Interval      time.Duration `yaml:"interval,omitempty"`
+	Timeout       time.Duration `yaml:"timeout,omitempty"`
+	Retries       int           `yaml:"retries,omitempty"`
+	StartPeriod   time.Duration `yaml:"start_period,omitempty"`
+	StartInterval time.Duration `yaml:"start_interval,omitempty"`
+	Disable       bool          `yaml:"disable,omitempty"`
 }
 
 // DeployConfig représente la configuration de déploiement
@@ -172,18 +191,29 @@ type IPAMPool struct {
 
 // Config représente une configuration Docker
 type Config struct {
-	File     string            `yaml:"file,omitempty"`
-	External interface{}       `yaml:"external,omitempty"` // bool ou ExternalConfig
-	Labels   map[string]string `yaml:"labels,omitempty"`
-	Name     string            `yaml:"name,omitempty"`
+	File        string            `yaml:"file,omitempty"`
+	Environment string            `yaml:"environment,omitempty"`
+	Content     string            `yaml:"content,omitempty"`
+	External    interface{}       `yaml:"external,omitempty"` // bool ou ExternalConfig
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Name        string            `yaml:"name,omitempty"`
+
+	// Data contenu résolu (content, fichier du bundle ou variable d'environnement), rempli par le parser
+	Data     string `yaml:"-"`
+	Resolved bool   `yaml:"-"`
 }
 
 // Secret représente un secret Docker
 type Secret struct {
-	File     string            `yaml:"file,omitempty"`
-	External interface{}       `yaml:"external,omitempty"` // bool ou ExternalConfig
-	Labels   map[string]string `yaml:"labels,omitempty"`
-	Name     string            `yaml:"name,omitempty"`
+	File        string            `yaml:"file,omitempty"`
+	Environment string            `yaml:"environment,omitempty"`
+	External    interface{}       `yaml:"external,omitempty"` // bool ou ExternalConfig
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Name        string            `yaml:"name,omitempty"`
+
+	// Data contenu résolu (fichier du bundle ou variable d'environnement), rempli par le parser
+	Data     string `yaml:"-"`
+	Resolved bool   `yaml:"-"`
 }
 
 // ExternalConfig représente la configuration d'une ressource externe
@@ -0,0 +1,219 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Types de montage supportés par la spécification Compose
+const (
+	VolumeTypeVolume = "volume"
+	VolumeTypeBind   = "bind"
+	VolumeTypeTmpfs  = "tmpfs"
+	VolumeTypeNpipe  = "npipe"
+)
+
+// VolumeConfig représente un montage de volume d'un service (syntaxe courte ou longue)
+type VolumeConfig struct {
+	Type        string               `yaml:"type"`
+	Source      string               `yaml:"source,omitempty"`
+	Target      string               `yaml:"target"`
+	ReadOnly    bool                 `yaml:"read_only,omitempty"`
+	Consistency string               `yaml:"consistency,omitempty"`
+	Bind        *VolumeBindOptions   `yaml:"bind,omitempty"`
+	Volume      *VolumeVolumeOptions `yaml:"volume,omitempty"`
+	Tmpfs       *VolumeTmpfsOptions  `yaml:"tmpfs,omitempty"`
+}
+
+// VolumeBindOptions représente les options d'un bind mount
+type VolumeBindOptions struct {
+	Propagation    string `yaml:"propagation,omitempty"`
+	CreateHostPath bool   `yaml:"create_host_path,omitempty"`
+	SELinux        string `yaml:"selinux,omitempty"`
+}
+
+// VolumeVolumeOptions représente les options d'un volume nommé
+type VolumeVolumeOptions struct {
+	NoCopy  bool   `yaml:"nocopy,omitempty"`
+	Subpath string `yaml:"subpath,omitempty"`
+}
+
+// VolumeTmpfsOptions représente les options d'un montage tmpfs
+type VolumeTmpfsOptions struct {
+	Size string `yaml:"size,omitempty"` // octets ou valeur avec unité ("64m")
+	Mode uint32 `yaml:"mode,omitempty"`
+}
+
+// IsNamedVolume indique si le montage référence un volume nommé
+func (v VolumeConfig) IsNamedVolume() bool {
+	return v.Type == VolumeTypeVolume && v.Source != ""
+}
+
+// VolumeList représente la liste des montages d'un service
+type VolumeList []VolumeConfig
+
+// UnmarshalYAML décode les syntaxes courte ("data:/var/lib/data:ro") et longue (type, source, target...)
+func (l *VolumeList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: volumes must be a list", value.Line)
+	}
+
+	var volumes VolumeList
+	for _, item := range value.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			volume, err := ParseVolumeMapping(item.Value)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			volumes = append(volumes, *volume)
+		case yaml.MappingNode:
+			var volume VolumeConfig
+			if err := item.Decode(&volume); err != nil {
+				return err
+			}
+			if err := validateVolumeConfig(&volume); err != nil {
+				return fmt.Errorf("line %d: %w", item.Line, err)
+			}
+			volumes = append(volumes, volume)
+		default:
+			return fmt.Errorf("line %d: invalid volume definition", item.Line)
+		}
+	}
+
+	*l = volumes
+	return nil
+}
+
+// ParseVolumeMapping parse un montage en syntaxe courte : [SOURCE:]TARGET[:MODE]
+func ParseVolumeMapping(mapping string) (*VolumeConfig, error) {
+	spec := strings.TrimSpace(mapping)
+	if spec == "" {
+		return nil, fmt.Errorf("empty volume definition")
+	}
+
+	parts := splitVolumeSpec(spec)
+
+	volume := &VolumeConfig{}
+	var mode string
+
+	switch len(parts) {
+	case 1:
+		// Volume anonyme monté sur le chemin cible
+		volume.Type = VolumeTypeVolume
+		volume.Target = parts[0]
+	case 2, 3:
+		volume.Source = parts[0]
+		volume.Target = parts[1]
+		if len(parts) == 3 {
+			mode = parts[2]
+		}
+		if isHostPath(volume.Source) {
+			volume.Type = VolumeTypeBind
+		} else {
+			volume.Type = VolumeTypeVolume
+		}
+	default:
+		return nil, fmt.Errorf("invalid volume definition %q", mapping)
+	}
+
+	if volume.Target == "" {
+		return nil, fmt.Errorf("invalid volume definition %q: missing target path", mapping)
+	}
+
+	for _, option := range strings.Split(mode, ",") {
+		switch option {
+		case "":
+		case "ro":
+			volume.ReadOnly = true
+		case "rw":
+			volume.ReadOnly = false
+		case "nocopy":
+			if volume.Volume == nil {
+				volume.Volume = &VolumeVolumeOptions{}
+			}
+			volume.Volume.NoCopy = true
+		case "z", "Z":
+			if volume.Bind == nil {
+				volume.Bind = &VolumeBindOptions{}
+			}
+			volume.Bind.SELinux = option
+		case "shared", "rshared", "slave", "rslave", "private", "rprivate":
+			if volume.Bind == nil {
+				volume.Bind = &VolumeBindOptions{}
+			}
+			volume.Bind.Propagation = option
+		case "cached", "delegated", "consistent":
+			volume.Consistency = option
+		default:
+			return nil, fmt.Errorf("invalid volume mode %q in %q", option, mapping)
+		}
+	}
+
+	return volume, nil
+}
+
+// splitVolumeSpec découpe une définition courte en tenant compte des lettres de lecteur Windows (C:\data)
+func splitVolumeSpec(spec string) []string {
+	var parts []string
+	for len(spec) > 0 {
+		if len(spec) >= 3 && isDriveLetter(spec[0]) && spec[1] == ':' && (spec[2] == '\\' || spec[2] == '/') {
+			next := strings.Index(spec[2:], ":")
+			if next < 0 {
+				parts = append(parts, spec)
+				break
+			}
+			parts = append(parts, spec[:next+2])
+			spec = spec[next+3:]
+			continue
+		}
+
+		next := strings.Index(spec, ":")
+		if next < 0 {
+			parts = append(parts, spec)
+			break
+		}
+		parts = append(parts, spec[:next])
+		spec = spec[next+1:]
+	}
+	return parts
+}
+
+// isHostPath indique si la source d'un montage désigne un chemin de l'hôte plutôt qu'un volume nommé
+func isHostPath(source string) bool {
+	if strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~") {
+		return true
+	}
+	return len(source) >= 2 && isDriveLetter(source[0]) && source[1] == ':'
+}
+
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// validateVolumeConfig valide un montage en syntaxe longue
+func validateVolumeConfig(volume *VolumeConfig) error {
+	switch volume.Type {
+	case VolumeTypeVolume, VolumeTypeBind, VolumeTypeTmpfs, VolumeTypeNpipe:
+	case "":
+		return fmt.Errorf("volume type is required")
+	default:
+		return fmt.Errorf("invalid volume type %q", volume.Type)
+	}
+
+	if volume.Target == "" {
+		return fmt.Errorf("volume target is required")
+	}
+
+	if (volume.Type == VolumeTypeBind || volume.Type == VolumeTypeNpipe) && volume.Source == "" {
+		return fmt.Errorf("%s mount requires a source", volume.Type)
+	}
+
+	if volume.Type == VolumeTypeTmpfs && volume.Source != "" {
+		return fmt.Errorf("tmpfs mount cannot have a source")
+	}
+
+	return nil
+}
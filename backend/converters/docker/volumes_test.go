@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVolumeMapping(t *testing.T) {
+	cases := []struct {
+		mapping  string
+		expected VolumeConfig
+	}{
+		{"/app/node_modules", VolumeConfig{Type: VolumeTypeVolume, Target: "/app/node_modules"}},
+		{"pgdata:/var/lib/postgresql/data", VolumeConfig{Type: VolumeTypeVolume, Source: "pgdata", Target: "/var/lib/postgresql/data"}},
+		{"./html:/usr/share/nginx/html:ro", VolumeConfig{Type: VolumeTypeBind, Source: "./html", Target: "/usr/share/nginx/html", ReadOnly: true}},
+		{"/var/run/docker.sock:/var/run/docker.sock:ro,rslave", VolumeConfig{
+			Type: VolumeTypeBind, Source: "/var/run/docker.sock", Target: "/var/run/docker.sock", ReadOnly: true,
+			Bind: &VolumeBindOptions{Propagation: "rslave"},
+		}},
+		{`C:\data:/data`, VolumeConfig{Type: VolumeTypeBind, Source: `C:\data`, Target: "/data"}},
+	}
+
+	for _, tc := range cases {
+		volume, err := ParseVolumeMapping(tc.mapping)
+		require.NoError(t, err, tc.mapping)
+		assert.Equal(t, tc.expected, *volume, tc.mapping)
+	}
+
+	_, err := ParseVolumeMapping("data:/data:bogus")
+	assert.Error(t, err)
+}
+
+func TestParseLongSyntaxVolumes(t *testing.T) {
+	compose, err := ParseDockerCompose(`services:
+  app:
+    image: app
+    volumes:
+      - type: tmpfs
+        target: /tmp
+        tmpfs:
+          size: 64m
+      - type: volume
+        source: cache
+        target: /cache
+        volume:
+          nocopy: true
+`)
+	require.NoError(t, err)
+
+	volumes := compose.Services["app"].Volumes
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "64m", volumes[0].Tmpfs.Size)
+	assert.True(t, volumes[1].IsNamedVolume())
+	assert.True(t, volumes[1].Volume.NoCopy)
+
+	_, err = ParseDockerCompose(`services:
+  app:
+    image: app
+    volumes:
+      - type: tmpfs
+        source: nope
+        target: /tmp
+`)
+	assert.Error(t, err)
+}
@@ -2,12 +2,16 @@ package converters
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"devops-converter/converters/docker"
 	"devops-converter/converters/kubernetes"
-
-	"gopkg.in/yaml.v3"
 )
 
 // DockerComposeToKubernetesConverter convertit docker-compose vers Kubernetes
@@ -41,13 +45,33 @@ func (c *DockerComposeToKubernetesConverter) GetSupportedTypes() []string {
 
 // Validate valide le contenu d'entrée
 func (c *DockerComposeToKubernetesConverter) Validate(ctx context.Context, content string, contentType string) error {
-	if contentType != "docker-compose" {
-		return fmt.Errorf("unsupported content type: %s", contentType)
+	return c.ValidateRequest(ctx, ConversionRequest{Type: contentType, Content: content})
+}
+
+// ValidateRequest valide une requête comme le ferait Convert : le .env des options, les overrides et
+// les fichiers du bundle sont pris en compte pour l'interpolation, include et extends
+func (c *DockerComposeToKubernetesConverter) ValidateRequest(ctx context.Context, req ConversionRequest) error {
+	if req.Type != "docker-compose" {
+		return fmt.Errorf("unsupported content type: %s", req.Type)
+	}
+
+	environment, err := c.extractEnvironment(req.Options)
+	if err != nil {
+		return ValidationErrors{{Code: "ENV_FILE_ERROR", Message: err.Error(), Field: "envFile"}}
+	}
+
+	composeFiles := c.collectComposeFiles(req)
+	if len(composeFiles) == 0 {
+		return ValidationErrors{{Code: "VALIDATION_ERROR", Message: "No docker-compose content provided", Field: "content"}}
 	}
 
-	_, err := docker.ParseDockerCompose(content)
+	// Les violations du schéma Compose et les erreurs de parsing sont toutes retournées
+	_, _, err = docker.ParseDockerComposeFiles(composeFiles, docker.ParseOptions{
+		Environment: environment,
+		Files:       c.collectBundleFiles(req),
+	})
 	if err != nil {
-		return fmt.Errorf("invalid docker-compose file: %w", err)
+		return ValidationErrors(parseErrorsToConversionErrors(err))
 	}
 
 	return nil
@@ -55,33 +79,73 @@ func (c *DockerComposeToKubernetesConverter) Validate(ctx context.Context, conte
 
 // Convert effectue la conversion
 func (c *DockerComposeToKubernetesConverter) Convert(ctx context.Context, req ConversionRequest) (*ConversionResult, error) {
-	// Valider la requête
-	if err := c.Validate(ctx, req.Content, req.Type); err != nil {
+	if req.Type != "docker-compose" {
 		return &ConversionResult{
 			Success: false,
 			Errors: []ConversionError{
 				{
 					Code:    "VALIDATION_ERROR",
-					Message: err.Error(),
+					Message: fmt.Sprintf("unsupported content type: %s", req.Type),
 				},
 			},
 		}, nil
 	}
 
-	// Parser le fichier docker-compose
-	dockerCompose, err := docker.ParseDockerCompose(req.Content)
+	// Construire l'environnement d'interpolation (.env fourni avec la requête)
+	environment, err := c.extractEnvironment(req.Options)
 	if err != nil {
 		return &ConversionResult{
 			Success: false,
 			Errors: []ConversionError{
 				{
-					Code:    "PARSE_ERROR",
-					Message: fmt.Sprintf("Failed to parse docker-compose file: %v", err),
+					Code:    "ENV_FILE_ERROR",
+					Message: err.Error(),
+					Field:   "envFile",
+				},
+			},
+		}, nil
+	}
+
+	// Fichiers docker-compose à fusionner : Content puis les overrides dans l'ordre
+	composeFiles := c.collectComposeFiles(req)
+	if len(composeFiles) == 0 {
+		return &ConversionResult{
+			Success: false,
+			Errors: []ConversionError{
+				{
+					Code:    "VALIDATION_ERROR",
+					Message: "No docker-compose content provided",
+					Field:   "content",
 				},
 			},
 		}, nil
 	}
 
+	// Parser et fusionner les fichiers docker-compose
+	dockerCompose, parseWarnings, err := docker.ParseDockerComposeFiles(composeFiles, docker.ParseOptions{
+		Environment: environment,
+		Files:       c.collectBundleFiles(req),
+	})
+	if err != nil {
+		return &ConversionResult{
+			Success:  false,
+			Errors:   parseErrorsToConversionErrors(err),
+			Warnings: diagnosticsToWarnings(parseWarnings),
+		}, nil
+	}
+
+	// Filtrer les services selon les profils actifs et la liste explicite de services
+	selection := c.extractServiceSelection(req.Options, environment)
+	excludedServices, selectionWarnings, err := docker.SelectServices(dockerCompose, selection)
+	if err != nil {
+		return &ConversionResult{
+			Success:  false,
+			Errors:   parseErrorsToConversionErrors(err),
+			Warnings: diagnosticsToWarnings(parseWarnings),
+		}, nil
+	}
+	parseWarnings = append(parseWarnings, selectionWarnings...)
+
 	// Extraire les options de conversion
 	options := c.extractGeneratorOptions(req.Options)
 
@@ -91,11 +155,237 @@ func (c *DockerComposeToKubernetesConverter) Convert(ctx context.Context, req Co
 	// Convertir en mode "all-in-one" ou séparé selon les options
 	useAllInOne := c.shouldUseAllInOne(req.Options)
 
+	var result *ConversionResult
 	if useAllInOne {
-		return c.convertToAllInOneFile(ctx, dockerCompose, options, projectName)
+		result, err = c.convertToAllInOneFile(ctx, dockerCompose, options, projectName)
 	} else {
-		return c.convertToSeparateFiles(ctx, dockerCompose, options)
+		result, err = c.convertToSeparateFiles(ctx, dockerCompose, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Warnings = append(diagnosticsToWarnings(parseWarnings), result.Warnings...)
+	locateIssues(result, dockerCompose.Positions)
+
+	// Rapport de couverture : chaque clé source est mappée, partiellement mappée ou ignorée
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["coverage"] = buildCoverageReport(dockerCompose)
+
+	if len(selection.Profiles) > 0 {
+		result.Metadata["active_profiles"] = selection.Profiles
+	}
+	if len(excludedServices) > 0 {
+		result.Metadata["excluded_services"] = excludedServices
+	}
+
+	if len(composeFiles) > 1 {
+		names := make([]string, len(composeFiles))
+		for i, file := range composeFiles {
+			names[i] = file.Name
+		}
+		result.Metadata["compose_files"] = names
+	}
+
+	return result, nil
+}
+
+// collectComposeFiles retourne la liste ordonnée des fichiers docker-compose de la requête
+func (c *DockerComposeToKubernetesConverter) collectComposeFiles(req ConversionRequest) []docker.ComposeFile {
+	var files []docker.ComposeFile
+
+	if strings.TrimSpace(req.Content) != "" {
+		name := req.Filename
+		if name == "" && len(req.Documents) > 0 {
+			name = "docker-compose.yml"
+		}
+		files = append(files, docker.ComposeFile{Name: name, Content: req.Content})
+	}
+
+	for i, document := range req.Documents {
+		name := document.Name
+		if name == "" {
+			name = fmt.Sprintf("document-%d", i+1)
+		}
+		files = append(files, docker.ComposeFile{Name: name, Content: document.Content})
+	}
+
+	return files
+}
+
+// collectBundleFiles retourne les fichiers du bundle indexés par nom, pour la résolution de extends et include
+func (c *DockerComposeToKubernetesConverter) collectBundleFiles(req ConversionRequest) map[string]string {
+	if len(req.Bundle) == 0 {
+		return nil
+	}
+
+	files := make(map[string]string, len(req.Bundle))
+	for _, file := range req.Bundle {
+		if file.Name != "" {
+			files[file.Name] = file.Content
+		}
+	}
+	return files
+}
+
+// extractEnvironment construit les variables d'interpolation à partir des options.
+// "envFile" contient le contenu d'un fichier .env, "env" une map de variables qui le surcharge.
+func (c *DockerComposeToKubernetesConverter) extractEnvironment(options map[string]interface{}) (map[string]string, error) {
+	environment := make(map[string]string)
+
+	if envFile, ok := options["envFile"].(string); ok && envFile != "" {
+		parsed, err := docker.ParseEnvFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range parsed {
+			environment[k] = v
+		}
 	}
+
+	if env, ok := options["env"].(map[string]interface{}); ok {
+		for k, v := range env {
+			environment[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return environment, nil
+}
+
+// parseErrorsToConversionErrors convertit une erreur de parsing en erreurs de conversion
+func parseErrorsToConversionErrors(err error) []ConversionError {
+	var parseErrors docker.ParseErrors
+	if !errors.As(err, &parseErrors) {
+		return []ConversionError{
+			{
+				Code:    "PARSE_ERROR",
+				Message: fmt.Sprintf("Failed to parse docker-compose file: %v", err),
+			},
+		}
+	}
+
+	conversionErrors := make([]ConversionError, 0, len(parseErrors))
+	for _, d := range parseErrors {
+		conversionErrors = append(conversionErrors, ConversionError{
+			Code:       d.Code,
+			Message:    d.Message,
+			File:       d.File,
+			Line:       d.Line,
+			Column:     d.Column,
+			Field:      d.Field,
+			Suggestion: d.Suggestion,
+		})
+	}
+	return conversionErrors
+}
+
+// diagnosticsToWarnings convertit des diagnostics du parser en avertissements de conversion
+func diagnosticsToWarnings(diagnostics []docker.Diagnostic) []ConversionWarning {
+	var warnings []ConversionWarning
+	for _, d := range diagnostics {
+		warnings = append(warnings, ConversionWarning{
+			Code:       d.Code,
+			Message:    d.Message,
+			File:       d.File,
+			Line:       d.Line,
+			Column:     d.Column,
+			Field:      d.Field,
+			Suggestion: d.Suggestion,
+		})
+	}
+	return warnings
+}
+
+// locateIssues complète la position source (fichier, ligne, colonne) des erreurs et avertissements
+// qui désignent un champ du fichier docker-compose
+func locateIssues(result *ConversionResult, positions docker.SourceMap) {
+	for i := range result.Errors {
+		issue := &result.Errors[i]
+		if issue.Line > 0 || issue.Field == "" {
+			continue
+		}
+		if position, ok := positions.Lookup(issue.Field); ok {
+			issue.Line, issue.Column = position.Line, position.Column
+			if issue.File == "" {
+				issue.File = position.File
+			}
+		}
+	}
+
+	for i := range result.Warnings {
+		issue := &result.Warnings[i]
+		if issue.Line > 0 || issue.Field == "" {
+			continue
+		}
+		if position, ok := positions.Lookup(issue.Field); ok {
+			issue.Line, issue.Column = position.Line, position.Column
+			if issue.File == "" {
+				issue.File = position.File
+			}
+		}
+	}
+}
+
+// extractServiceSelection lit les options "profiles" et "services" (liste ou chaîne séparée par des virgules).
+// À défaut d'option, la variable COMPOSE_PROFILES de l'environnement d'interpolation est utilisée.
+func (c *DockerComposeToKubernetesConverter) extractServiceSelection(options map[string]interface{}, environment map[string]string) docker.ServiceSelection {
+	selection := docker.ServiceSelection{
+		Profiles: stringListOption(options["profiles"]),
+		Services: stringListOption(options["services"]),
+	}
+
+	if len(selection.Profiles) == 0 {
+		selection.Profiles = stringListOption(environment["COMPOSE_PROFILES"])
+	}
+
+	return selection
+}
+
+// cleanAbsolutePaths normalise les chemins absolus ("/var/cache/" devient "/var/cache") et retire les
+// doublons ; les chemins relatifs sont conservés tels quels pour être signalés à la validation
+func cleanAbsolutePaths(paths []string) []string {
+	result := []string{}
+	seen := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		if path.IsAbs(p) {
+			p = path.Clean(p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// stringListOption convertit une option en liste de chaînes non vides
+func stringListOption(value interface{}) []string {
+	var items []string
+
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if str := strings.TrimSpace(fmt.Sprintf("%v", item)); str != "" {
+				items = append(items, str)
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
 }
 
 // extractProjectName extrait le nom du projet des options ou du docker-compose
@@ -126,23 +416,11 @@ func (c *DockerComposeToKubernetesConverter) shouldUseAllInOne(options map[strin
 
 // convertToAllInOneFile convertit en un seul fichier fusionné
 func (c *DockerComposeToKubernetesConverter) convertToAllInOneFile(ctx context.Context, dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions, projectName string) (*ConversionResult, error) {
-	var kubernetesObjects []kubernetes.KubernetesObject
-	var conversionErrors []ConversionError
-	var warnings []ConversionWarning
-
-	// Convertir chaque service
-	for serviceName, service := range dockerCompose.Services {
-		objects, errs, warns := c.convertServiceToObjects(serviceName, service, options)
-		kubernetesObjects = append(kubernetesObjects, objects...)
-		conversionErrors = append(conversionErrors, errs...)
-		warnings = append(warnings, warns...)
-	}
+	manifests, conversionErrors, warnings := c.convertManifests(dockerCompose, options)
 
-	// Générer les volumes globaux si nécessaire
-	if len(dockerCompose.Volumes) > 0 {
-		volumeObjects, volumeErrs := c.convertVolumesToObjects(dockerCompose.Volumes, options)
-		kubernetesObjects = append(kubernetesObjects, volumeObjects...)
-		conversionErrors = append(conversionErrors, volumeErrs...)
+	kubernetesObjects := make([]kubernetes.KubernetesObject, 0, len(manifests))
+	for _, m := range manifests {
+		kubernetesObjects = append(kubernetesObjects, m.object)
 	}
 
 	if len(kubernetesObjects) == 0 {
@@ -196,23 +474,10 @@ func (c *DockerComposeToKubernetesConverter) convertToAllInOneFile(ctx context.C
 
 // convertToSeparateFiles convertit en fichiers séparés (ancienne méthode)
 func (c *DockerComposeToKubernetesConverter) convertToSeparateFiles(ctx context.Context, dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions) (*ConversionResult, error) {
-	var generatedFiles []GeneratedFile
-	var conversionErrors []ConversionError
-	var warnings []ConversionWarning
-
-	for serviceName, service := range dockerCompose.Services {
-		files, errs, warns := c.convertService(serviceName, service, options)
-		generatedFiles = append(generatedFiles, files...)
-		conversionErrors = append(conversionErrors, errs...)
-		warnings = append(warnings, warns...)
-	}
+	manifests, conversionErrors, warnings := c.convertManifests(dockerCompose, options)
 
-	// Générer les volumes globaux si nécessaire
-	if len(dockerCompose.Volumes) > 0 {
-		volumeFiles, volumeErrs := c.convertVolumes(dockerCompose.Volumes, options)
-		generatedFiles = append(generatedFiles, volumeFiles...)
-		conversionErrors = append(conversionErrors, volumeErrs...)
-	}
+	generatedFiles, marshalErrors := manifestsToFiles(manifests)
+	conversionErrors = append(conversionErrors, marshalErrors...)
 
 	success := len(conversionErrors) == 0
 
@@ -230,183 +495,740 @@ func (c *DockerComposeToKubernetesConverter) convertToSeparateFiles(ctx context.
 	}, nil
 }
 
-// extractGeneratorOptions extrait les options du générateur
-func (c *DockerComposeToKubernetesConverter) extractGeneratorOptions(options map[string]interface{}) kubernetes.GeneratorOptions {
-	opts := kubernetes.DefaultGeneratorOptions()
+// manifest objet Kubernetes généré et emplacement de son fichier en mode fichiers séparés
+type manifest struct {
+	object kubernetes.KubernetesObject
+	// file nom du fichier sans extension, dir son répertoire et fileType le type annoncé au client
+	file     string
+	dir      string
+	fileType string
+	// field élément docker-compose à l'origine de l'objet
+	field string
+}
 
-	if namespace, ok := options["namespace"].(string); ok {
-		opts.Namespace = namespace
+// origin décrit l'élément docker-compose à l'origine de l'objet
+func (m manifest) origin() string {
+	if m.field == "" {
+		return "the project"
 	}
+	return m.field
+}
 
-	if labels, ok := options["labels"].(map[string]interface{}); ok {
-		opts.Labels = make(map[string]string)
-		for k, v := range labels {
-			opts.Labels[k] = fmt.Sprintf("%v", v)
-		}
-	}
+// convertManifests génère les objets Kubernetes de chaque service puis ceux du projet. Les deux modes
+// de sortie partagent ces objets et ne diffèrent que par leur sérialisation.
+func (c *DockerComposeToKubernetesConverter) convertManifests(dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions) ([]manifest, []ConversionError, []ConversionWarning) {
+	var manifests []manifest
+	var conversionErrors []ConversionError
+	var warnings []ConversionWarning
 
-	if annotations, ok := options["annotations"].(map[string]interface{}); ok {
-		opts.Annotations = make(map[string]string)
-		for k, v := range annotations {
-			opts.Annotations[k] = fmt.Sprintf("%v", v)
-		}
-	}
+	// Conversion des services en map[string]interface{} pour les générateurs
+	services := c.servicesToMap(dockerCompose, options)
 
-	if imagePullPolicy, ok := options["imagePullPolicy"].(string); ok {
-		opts.ImagePullPolicy = imagePullPolicy
+	// Convertir chaque service
+	for serviceName, service := range dockerCompose.Services {
+		serviceManifests, errs, warns := c.convertService(serviceName, service, services[serviceName].(map[string]interface{}), options)
+		manifests = append(manifests, serviceManifests...)
+		conversionErrors = append(conversionErrors, errs...)
+		warnings = append(warnings, warns...)
 	}
 
-	if serviceType, ok := options["serviceType"].(string); ok {
-		opts.ServiceType = serviceType
-	}
+	projectManifests, errs, warns := c.convertProject(dockerCompose, services, options)
+	manifests = append(manifests, projectManifests...)
+	conversionErrors = append(conversionErrors, errs...)
+	warnings = append(warnings, warns...)
 
-	if replicas, ok := options["replicas"].(float64); ok {
-		opts.Replicas = int32(replicas)
-	}
+	conversionErrors = append(conversionErrors, duplicateObjectErrors(manifests)...)
 
-	return opts
+	return manifests, conversionErrors, warnings
 }
 
-// convertService convertit un service Docker Compose vers Kubernetes
-func (c *DockerComposeToKubernetesConverter) convertService(serviceName string, service docker.Service, options kubernetes.GeneratorOptions) ([]GeneratedFile, []ConversionError, []ConversionWarning) {
-	var files []GeneratedFile
+// duplicateObjectErrors signale les objets de même type et de même nom, par exemple le Secret d'un
+// secret de premier niveau nommé comme le Secret des variables d'un service : appliqué après l'autre,
+// l'un des deux objets écraserait le second dans le cluster
+func duplicateObjectErrors(manifests []manifest) []ConversionError {
 	var errors []ConversionError
-	var warnings []ConversionWarning
-
-	// Conversion du service en map[string]interface{} pour le générateur
-	serviceData := c.serviceToMap(service)
+	owners := make(map[string]manifest)
 
-	// Générer le Deployment
-	deployment, err := kubernetes.GenerateDeployment(serviceName, serviceData, options)
-	if err != nil {
+	for _, m := range manifests {
+		key := m.object.GetKind() + "/" + m.object.GetName()
+		owner, ok := owners[key]
+		if !ok {
+			owners[key] = m
+			continue
+		}
 		errors = append(errors, ConversionError{
-			Code:    "DEPLOYMENT_GENERATION_ERROR",
-			Message: fmt.Sprintf("Failed to generate deployment for service %s: %v", serviceName, err),
+			Code:       "DUPLICATE_OBJECT_NAME",
+			Message:    fmt.Sprintf("%s %s generated for %s has the same name as the one generated for %s", m.object.GetKind(), m.object.GetName(), m.origin(), owner.origin()),
+			Suggestion: "Rename one of the docker-compose elements, or set a different name on the top-level secret or config",
+			Field:      m.field,
 		})
-	} else {
-		deploymentYAML, err := yaml.Marshal(deployment)
-		if err != nil {
-			errors = append(errors, ConversionError{
-				Code:    "YAML_MARSHAL_ERROR",
-				Message: fmt.Sprintf("Failed to marshal deployment for service %s: %v", serviceName, err),
-			})
-		} else {
-			files = append(files, GeneratedFile{
-				Name:    fmt.Sprintf("%s-deployment.yaml", serviceName),
-				Content: string(deploymentYAML),
-				Type:    "deployment",
-				Path:    fmt.Sprintf("deployments/%s-deployment.yaml", serviceName),
-			})
-		}
 	}
 
-	// Générer le Service si nécessaire
-	kubernetesService, err := kubernetes.GenerateService(serviceName, serviceData, options)
-	if err != nil {
-		errors = append(errors, ConversionError{
-			Code:    "SERVICE_GENERATION_ERROR",
-			Message: fmt.Sprintf("Failed to generate service for %s: %v", serviceName, err),
-		})
-	} else if kubernetesService != nil {
-		serviceYAML, err := yaml.Marshal(kubernetesService)
+	return errors
+}
+
+// manifestsToFiles sérialise chaque objet dans son propre fichier
+func manifestsToFiles(manifests []manifest) ([]GeneratedFile, []ConversionError) {
+	var files []GeneratedFile
+	var errors []ConversionError
+
+	for _, m := range manifests {
+		content, err := m.object.ToYAML()
 		if err != nil {
 			errors = append(errors, ConversionError{
 				Code:    "YAML_MARSHAL_ERROR",
-				Message: fmt.Sprintf("Failed to marshal service for %s: %v", serviceName, err),
-			})
-		} else {
-			files = append(files, GeneratedFile{
-				Name:    fmt.Sprintf("%s-service.yaml", serviceName),
-				Content: string(serviceYAML),
-				Type:    "service",
-				Path:    fmt.Sprintf("services/%s-service.yaml", serviceName),
-			})
-		}
-	}
-
-	// Générer la ConfigMap si nécessaire
-	configMap, err := kubernetes.GenerateConfigMap(serviceName, serviceData, options)
-	if err != nil {
-		warnings = append(warnings, ConversionWarning{
-			Code:    "CONFIGMAP_GENERATION_WARNING",
-			Message: fmt.Sprintf("Failed to generate configmap for %s: %v", serviceName, err),
-		})
-	} else if configMap != nil {
-		configMapYAML, err := yaml.Marshal(configMap)
-		if err != nil {
-			warnings = append(warnings, ConversionWarning{
-				Code:    "YAML_MARSHAL_WARNING",
-				Message: fmt.Sprintf("Failed to marshal configmap for %s: %v", serviceName, err),
-			})
-		} else {
-			files = append(files, GeneratedFile{
-				Name:    fmt.Sprintf("%s-configmap.yaml", serviceName),
-				Content: string(configMapYAML),
-				Type:    "configmap",
-				Path:    fmt.Sprintf("configmaps/%s-configmap.yaml", serviceName),
+				Message: fmt.Sprintf("Failed to marshal %s %s: %v", m.object.GetKind(), m.object.GetName(), err),
+				Field:   m.field,
 			})
+			continue
 		}
-	}
 
-	// Générer les PVCs si nécessaire
-	pvcs, err := kubernetes.GeneratePersistentVolumeClaim(serviceName, serviceData, options)
-	if err != nil {
-		warnings = append(warnings, ConversionWarning{
-			Code:    "PVC_GENERATION_WARNING",
-			Message: fmt.Sprintf("Failed to generate PVCs for %s: %v", serviceName, err),
+		files = append(files, GeneratedFile{
+			Name:    m.file + ".yaml",
+			Content: content,
+			Type:    m.fileType,
+			Path:    fmt.Sprintf("%s/%s.yaml", m.dir, m.file),
 		})
-	} else {
-		for i, pvc := range pvcs {
-			pvcYAML, err := yaml.Marshal(pvc)
-			if err != nil {
-				warnings = append(warnings, ConversionWarning{
-					Code:    "YAML_MARSHAL_WARNING",
-					Message: fmt.Sprintf("Failed to marshal PVC %d for %s: %v", i, serviceName, err),
-				})
-				continue
-			}
-			files = append(files, GeneratedFile{
-				Name:    fmt.Sprintf("%s-pvc-%d.yaml", serviceName, i),
-				Content: string(pvcYAML),
-				Type:    "persistentvolumeclaim",
-				Path:    fmt.Sprintf("pvcs/%s-pvc-%d.yaml", serviceName, i),
-			})
-		}
 	}
 
-	// Ajouter des avertissements pour les fonctionnalités non supportées
-	warnings = append(warnings, c.checkUnsupportedFeatures(serviceName, service)...)
+	return files, errors
+}
 
-	return files, errors, warnings
+// projectManifest objet de niveau projet, dont le fichier est nommé d'après l'objet
+func projectManifest(object kubernetes.KubernetesObject, suffix, dir, fileType string) manifest {
+	return manifest{object: object, file: object.GetName() + "-" + suffix, dir: dir, fileType: fileType}
 }
 
-// serviceToMap convertit un service Docker en map pour le générateur
-func (c *DockerComposeToKubernetesConverter) serviceToMap(service docker.Service) map[string]interface{} {
-	// Conversion manuelle pour simplifier
-	result := make(map[string]interface{})
+// convertProject génère les objets partagés par les services : volumes, secrets et configs de premier
+// niveau, Gateway, Services des alias, NetworkPolicies et droits des init containers
+func (c *DockerComposeToKubernetesConverter) convertProject(dockerCompose *docker.DockerCompose, services map[string]interface{}, options kubernetes.GeneratorOptions) ([]manifest, []ConversionError, []ConversionWarning) {
+	var manifests []manifest
+	var conversionErrors []ConversionError
+	var warnings []ConversionWarning
 
-	if service.Image != "" {
-		result["image"] = service.Image
+	// Générer un PVC par volume nommé, partagé par les services qui le montent
+	conversionErrors = append(conversionErrors, c.checkVolumes(dockerCompose.Volumes)...)
+	claims, sharedVolumes := kubernetes.GeneratePersistentVolumeClaims(services, volumesToMap(dockerCompose.Volumes), options)
+	warnings = append(warnings, sharedVolumeWarnings(sharedVolumes)...)
+	for _, claim := range claims {
+		manifests = append(manifests, projectManifest(claim, "pvc", "pvcs", "persistentvolumeclaim"))
 	}
 
-	if len(service.Ports) > 0 {
-		ports := make([]interface{}, len(service.Ports))
-		for i, port := range service.Ports {
-			ports[i] = port
-		}
-		result["ports"] = ports
+	// Générer les Secrets et ConfigMaps des secrets et configs de premier niveau
+	resources, resourceWarnings := c.convertFileResources(dockerCompose, options)
+	warnings = append(warnings, resourceWarnings...)
+	manifests = append(manifests, resources...)
+
+	// Générer la Gateway partagée par les HTTPRoute
+	gateway, err := kubernetes.GenerateGateway(options)
+	if err != nil {
+		conversionErrors = append(conversionErrors, gatewayError(err))
+	} else if gateway != nil {
+		manifests = append(manifests, projectManifest(gateway, "gateway", "gateways", "gateway"))
 	}
 
-	if service.Environment != nil {
-		result["environment"] = service.Environment
+	// Vérifier le niveau de durcissement demandé
+	if err := kubernetes.ValidateHardeningLevel(options.Hardening.Level); err != nil {
+		conversionErrors = append(conversionErrors, hardeningLevelError(err))
+	}
+	if err := kubernetes.ValidateHardeningScratchPaths(options.Hardening.ScratchPaths); err != nil {
+		conversionErrors = append(conversionErrors, ConversionError{
+			Code:    "INVALID_HARDENING_SCRATCH_PATH",
+			Message: fmt.Sprintf("Failed to harden pods: %v", err),
+			Field:   "hardeningScratchPaths",
+		})
 	}
 
-	if len(service.Volumes) > 0 {
-		volumes := make([]interface{}, len(service.Volumes))
-		for i, volume := range service.Volumes {
-			volumes[i] = volume
-		}
-		result["volumes"] = volumes
+	// Générer les Services des alias réseau et des liens
+	aliasServices, conflicts := kubernetes.GenerateAliasServices(services, options)
+	aliasErrors, aliasWarnings := aliasConflictIssues(conflicts)
+	conversionErrors = append(conversionErrors, aliasErrors...)
+	warnings = append(warnings, aliasWarnings...)
+	for _, aliasService := range aliasServices {
+		manifests = append(manifests, projectManifest(aliasService, "service", "services", "service"))
+	}
+
+	// Générer les NetworkPolicies issues des réseaux docker-compose
+	if options.NetworkPolicies {
+		policies, err := kubernetes.GenerateNetworkPolicies(services, networksToMap(dockerCompose.Networks), options)
+		if err != nil {
+			conversionErrors = append(conversionErrors, networkPolicyError(err))
+		}
+		for _, policy := range policies {
+			manifests = append(manifests, projectManifest(policy, "networkpolicy", "networkpolicies", "networkpolicy"))
+		}
+		warnings = append(warnings, publishedPortsWarnings(dockerCompose, options)...)
+	}
+
+	// Générer les droits des init containers attendant la complétion d'un Job
+	for _, object := range kubernetes.GenerateDependencyWaiterRBAC(services, options) {
+		kind := strings.ToLower(object.GetKind())
+		manifests = append(manifests, projectManifest(object, kind, "rbac", kind))
+	}
+
+	return manifests, conversionErrors, warnings
+}
+
+// gatewayError signale une option d'exposition invalide
+func gatewayError(err error) ConversionError {
+	return ConversionError{
+		Code:    "INVALID_EXPOSURE_MODE",
+		Message: fmt.Sprintf("Failed to generate gateway: %v", err),
+		Field:   "exposureMode",
+	}
+}
+
+// hardeningLevelError signale un niveau de durcissement inconnu
+func hardeningLevelError(err error) ConversionError {
+	return ConversionError{
+		Code:    "INVALID_HARDENING_LEVEL",
+		Message: fmt.Sprintf("Failed to harden pods: %v", err),
+		Field:   "hardening",
+	}
+}
+
+// publishedPortsWarnings signale les ports publiés qu'aucune NetworkPolicy n'ouvre, faute de source
+// configurée pour le contrôleur d'ingress
+func publishedPortsWarnings(dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions) []ConversionWarning {
+	source := options.PublishedPortsSource
+	if source.Namespace != "" || len(source.PodLabels) > 0 {
+		return nil
+	}
+
+	var warnings []ConversionWarning
+	for _, name := range sortedMapKeys(dockerCompose.Services) {
+		if len(dockerCompose.Services[name].Ports) == 0 {
+			continue
+		}
+		warnings = append(warnings, ConversionWarning{
+			Code:       "PUBLISHED_PORTS_ISOLATED",
+			Message:    fmt.Sprintf("Published ports of %s are only reachable from services on the same networks", name),
+			Suggestion: "Set networkPolicyIngressNamespace or networkPolicyIngressPodLabels to let the ingress controller reach them",
+			Field:      docker.ServiceField(name, "ports"),
+		})
+	}
+	return warnings
+}
+
+// networkPolicyError signale l'échec de génération des NetworkPolicies
+func networkPolicyError(err error) ConversionError {
+	return ConversionError{
+		Code:    "NETWORK_POLICY_GENERATION_ERROR",
+		Message: fmt.Sprintf("Failed to generate network policies: %v", err),
+		Field:   "/networks",
+	}
+}
+
+// sharedVolumeWarnings signale les volumes montés par plusieurs pods, dont le PVC demande ReadWriteMany
+func sharedVolumeWarnings(sharedVolumes []kubernetes.SharedVolume) []ConversionWarning {
+	warnings := make([]ConversionWarning, 0, len(sharedVolumes))
+	for _, shared := range sharedVolumes {
+		warnings = append(warnings, ConversionWarning{
+			Code:       "SHARED_VOLUME_ACCESS_MODE",
+			Message:    fmt.Sprintf("Volume %s is mounted by several pods (%s); its claim requests ReadWriteMany access", shared.Volume, strings.Join(shared.Services, ", ")),
+			Suggestion: "Use a storage class that supports ReadWriteMany (NFS, CephFS, EFS...); with ReadWriteOnce, every pod must be scheduled on the same node",
+			Field:      docker.FieldPointer("volumes", shared.Volume),
+		})
+	}
+	return warnings
+}
+
+// aliasConflictIssues signale les alias qui ne peuvent pas devenir des Services : les collisions sont
+// des erreurs, les noms invalides de simples avertissements puisque l'alias est ignoré
+func aliasConflictIssues(conflicts []kubernetes.AliasConflict) ([]ConversionError, []ConversionWarning) {
+	var errors []ConversionError
+	var warnings []ConversionWarning
+	for _, conflict := range conflicts {
+		if conflict.Unsupported {
+			warnings = append(warnings, ConversionWarning{
+				Code:       "UNSUPPORTED_ALIAS",
+				Message:    fmt.Sprintf("%s; the alias is skipped", conflict.Error()),
+				Suggestion: "Use an alias made of lowercase letters, digits and '-', or reach the service by its name",
+				Field:      docker.ServiceField(conflict.Service, conflict.Path...),
+			})
+			continue
+		}
+		errors = append(errors, ConversionError{
+			Code:    "ALIAS_CONFLICT",
+			Message: conflict.Error(),
+			Field:   docker.ServiceField(conflict.Service, conflict.Path...),
+		})
+	}
+	return errors, warnings
+}
+
+// servicesToMap convertit tous les services pour les générateurs. Chaque dépendance (depends_on)
+// est complétée par le type de workload et les ports du service ciblé pour générer les init containers,
+// et les volumes nommés montés par plusieurs services sont marqués comme partagés.
+func (c *DockerComposeToKubernetesConverter) servicesToMap(dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions) map[string]interface{} {
+	services := make(map[string]interface{}, len(dockerCompose.Services))
+	for name, service := range dockerCompose.Services {
+		services[name] = c.serviceToMap(service)
+	}
+	kubernetes.MarkSharedVolumes(services)
+
+	for _, service := range services {
+		dependencies, _ := service.(map[string]interface{})["depends_on"].(map[string]interface{})
+		for name, dependency := range dependencies {
+			target, ok := services[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			kind, err := kubernetes.ResolveWorkloadKind(name, target, options)
+			if err != nil {
+				continue
+			}
+			dependency := dependency.(map[string]interface{})
+			dependency["kind"] = kind
+			if ports, ok := target["ports"]; ok {
+				dependency["ports"] = ports
+			}
+		}
+	}
+
+	return services
+}
+
+// volumesToMap convertit les volumes de premier niveau pour le générateur de PVCs
+func volumesToMap(volumes map[string]docker.Volume) map[string]interface{} {
+	result := make(map[string]interface{}, len(volumes))
+	for name, volume := range volumes {
+		result[name] = map[string]interface{}{"external": docker.IsExternal(volume.External)}
+	}
+	return result
+}
+
+// networksToMap convertit les réseaux de premier niveau pour le générateur de NetworkPolicies
+func networksToMap(networks map[string]docker.Network) map[string]interface{} {
+	result := make(map[string]interface{}, len(networks))
+	for name, network := range networks {
+		result[name] = map[string]interface{}{"internal": network.Internal}
+	}
+	return result
+}
+
+// extractGeneratorOptions extrait les options du générateur
+func (c *DockerComposeToKubernetesConverter) extractGeneratorOptions(options map[string]interface{}) kubernetes.GeneratorOptions {
+	opts := kubernetes.DefaultGeneratorOptions()
+
+	if namespace, ok := options["namespace"].(string); ok {
+		opts.Namespace = namespace
+	}
+
+	if labels, ok := options["labels"].(map[string]interface{}); ok {
+		opts.Labels = make(map[string]string)
+		for k, v := range labels {
+			opts.Labels[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if annotations, ok := options["annotations"].(map[string]interface{}); ok {
+		opts.Annotations = make(map[string]string)
+		for k, v := range annotations {
+			opts.Annotations[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if imagePullPolicy, ok := options["imagePullPolicy"].(string); ok {
+		opts.ImagePullPolicy = imagePullPolicy
+	}
+
+	if serviceType, ok := options["serviceType"].(string); ok {
+		opts.ServiceType = serviceType
+	}
+
+	if replicas, ok := options["replicas"].(float64); ok {
+		opts.Replicas = int32(replicas)
+	}
+
+	if statefulServices, ok := options["statefulServices"]; ok {
+		opts.StatefulServices = stringListOption(statefulServices)
+	}
+
+	if namedVolumes, ok := options["namedVolumesAsStatefulSet"].(bool); ok {
+		opts.NamedVolumesAsStatefulSet = namedVolumes
+	}
+
+	// Une liste vide désactive la détection des variables sensibles
+	if secretPatterns, ok := options["secretPatterns"]; ok {
+		opts.SecretPatterns = append([]string{}, stringListOption(secretPatterns)...)
+	}
+
+	// Exposition HTTP des services via des Ingress
+	if ingress, ok := options["ingress"].(bool); ok {
+		opts.Ingress.Enabled = ingress
+	}
+	if domain, ok := options["ingressDomain"].(string); ok {
+		opts.Ingress.Domain = domain
+	}
+	if hosts, ok := options["ingressHosts"].(map[string]interface{}); ok {
+		opts.Ingress.Hosts = make(map[string]string)
+		for service, host := range hosts {
+			opts.Ingress.Hosts[service] = fmt.Sprintf("%v", host)
+		}
+	}
+	if className, ok := options["ingressClass"].(string); ok {
+		opts.Ingress.ClassName = className
+	}
+	if tlsSecret, ok := options["ingressTLSSecret"].(string); ok {
+		opts.Ingress.TLSSecret = tlsSecret
+	}
+	if pathPrefix, ok := options["ingressPathPrefix"].(string); ok {
+		opts.Ingress.PathPrefix = pathPrefix
+	}
+	if issuer, ok := options["certManagerIssuer"].(string); ok {
+		opts.Ingress.CertManagerIssuer = issuer
+	}
+	if clusterIssuer, ok := options["certManagerClusterIssuer"].(string); ok {
+		opts.Ingress.CertManagerClusterIssuer = clusterIssuer
+	}
+
+	// Mode Gateway API : HTTPRoute rattachées à une Gateway au lieu d'Ingress
+	if mode, ok := options["exposureMode"].(string); ok {
+		opts.Ingress.Mode = mode
+	}
+	if gatewayName, ok := options["gatewayName"].(string); ok {
+		opts.Ingress.GatewayName = gatewayName
+	}
+	if gatewayNamespace, ok := options["gatewayNamespace"].(string); ok {
+		opts.Ingress.GatewayNamespace = gatewayNamespace
+	}
+	if gatewayClass, ok := options["gatewayClass"].(string); ok {
+		opts.Ingress.GatewayClassName = gatewayClass
+	}
+
+	// NetworkPolicies issues des réseaux docker-compose
+	if networkPolicies, ok := options["networkPolicies"].(bool); ok {
+		opts.NetworkPolicies = networkPolicies
+	}
+	if namespace, ok := options["networkPolicyIngressNamespace"].(string); ok {
+		opts.PublishedPortsSource.Namespace = namespace
+	}
+	if podLabels, ok := options["networkPolicyIngressPodLabels"].(map[string]interface{}); ok {
+		opts.PublishedPortsSource.PodLabels = make(map[string]string)
+		for k, v := range podLabels {
+			opts.PublishedPortsSource.PodLabels[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	// Durcissement des pods selon les Pod Security Standards
+	if hardening, ok := options["hardening"].(string); ok {
+		opts.Hardening.Level = hardening
+	}
+	if scratchPaths, ok := options["hardeningScratchPaths"]; ok {
+		opts.Hardening.ScratchPaths = cleanAbsolutePaths(stringListOption(scratchPaths))
+	}
+
+	// Init containers attendant les dépendances ; le délai est en secondes ou une durée ("5m")
+	if image, ok := options["dependencyWaitImage"].(string); ok && image != "" {
+		opts.DependencyWait.Image = image
+	}
+	if image, ok := options["dependencyJobWaitImage"].(string); ok && image != "" {
+		opts.DependencyWait.JobImage = image
+	}
+	switch timeout := options["dependencyWaitTimeout"].(type) {
+	case float64:
+		if timeout >= 0 {
+			opts.DependencyWait.TimeoutSeconds = int32(timeout)
+		}
+	case string:
+		if duration, err := time.ParseDuration(timeout); err == nil && duration >= 0 {
+			opts.DependencyWait.TimeoutSeconds = int32(duration.Seconds())
+		}
+	}
+
+	// HorizontalPodAutoscalers : options globales puis surcharges par service
+	if autoscaling, ok := options["autoscaling"].(bool); ok {
+		opts.Autoscaling.Enabled = autoscaling
+	}
+	opts.Autoscaling.Defaults = autoscalingTargetsOption(map[string]interface{}{
+		"minReplicas":  options["hpaMinReplicas"],
+		"maxReplicas":  options["hpaMaxReplicas"],
+		"targetCPU":    options["hpaTargetCPU"],
+		"targetMemory": options["hpaTargetMemory"],
+	})
+	if services, ok := options["autoscalingServices"].(map[string]interface{}); ok {
+		opts.Autoscaling.Services = make(map[string]kubernetes.AutoscalingTargets)
+		for service, targets := range services {
+			if targets, ok := targets.(map[string]interface{}); ok {
+				opts.Autoscaling.Services[service] = autoscalingTargetsOption(targets)
+			}
+		}
+	}
+
+	// PodDisruptionBudgets : options globales puis surcharges par service
+	if pdb, ok := options["podDisruptionBudget"].(bool); ok {
+		opts.DisruptionBudget.Enabled = pdb
+	}
+	opts.DisruptionBudget.Defaults = disruptionBudgetOption(map[string]interface{}{
+		"minAvailable":   options["pdbMinAvailable"],
+		"maxUnavailable": options["pdbMaxUnavailable"],
+	})
+	if services, ok := options["podDisruptionBudgetServices"].(map[string]interface{}); ok {
+		opts.DisruptionBudget.Services = make(map[string]kubernetes.DisruptionBudget)
+		for service, budget := range services {
+			if budget, ok := budget.(map[string]interface{}); ok {
+				opts.DisruptionBudget.Services[service] = disruptionBudgetOption(budget)
+			}
+		}
+	}
+
+	return opts
+}
+
+// autoscalingTargetsOption lit les cibles d'un HPA (minReplicas, maxReplicas, targetCPU, targetMemory)
+func autoscalingTargetsOption(values map[string]interface{}) kubernetes.AutoscalingTargets {
+	number := func(key string) int32 {
+		if value, ok := values[key].(float64); ok && value > 0 {
+			return int32(value)
+		}
+		return 0
+	}
+	return kubernetes.AutoscalingTargets{
+		MinReplicas:  number("minReplicas"),
+		MaxReplicas:  number("maxReplicas"),
+		TargetCPU:    number("targetCPU"),
+		TargetMemory: number("targetMemory"),
+	}
+}
+
+// disruptionBudgetOption lit un budget de disruption ; les valeurs sont des entiers ou des pourcentages
+func disruptionBudgetOption(values map[string]interface{}) kubernetes.DisruptionBudget {
+	value := func(key string) string {
+		switch v := values[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.Itoa(int(v))
+		default:
+			return ""
+		}
+	}
+	return kubernetes.DisruptionBudget{
+		MinAvailable:   value("minAvailable"),
+		MaxUnavailable: value("maxUnavailable"),
+	}
+}
+
+// convertService convertit un service Docker Compose en objets Kubernetes
+func (c *DockerComposeToKubernetesConverter) convertService(serviceName string, service docker.Service, serviceData map[string]interface{}, options kubernetes.GeneratorOptions) ([]manifest, []ConversionError, []ConversionWarning) {
+	var manifests []manifest
+	var errors []ConversionError
+	var warnings []ConversionWarning
+
+	// add enregistre un objet du service, dont le fichier est nommé d'après le service comme ses objets
+	add := func(object kubernetes.KubernetesObject, suffix, dir, fileType string) {
+		manifests = append(manifests, manifest{
+			object:   object,
+			file:     kubernetes.ServiceObjectName(serviceName, suffix),
+			dir:      dir,
+			fileType: fileType,
+			field:    docker.ServiceField(serviceName),
+		})
+	}
+
+	// Générer le workload (Deployment, StatefulSet, DaemonSet, Job ou CronJob)
+	kind, err := kubernetes.ResolveWorkloadKind(serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "INVALID_WORKLOAD_TYPE",
+			Message: fmt.Sprintf("Failed to resolve workload type for service %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "labels", kubernetes.WorkloadTypeLabel),
+		})
+	} else if workload, err := kubernetes.GenerateWorkload(kind, serviceName, serviceData, options); err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "DEPLOYMENT_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate %s for service %s: %v", kind, serviceName, err),
+			Field:   docker.ServiceField(serviceName),
+		})
+	} else {
+		add(workload, kind, kind+"s", kind)
+	}
+
+	// Générer le HPA et le PDB d'un Deployment ou d'un StatefulSet
+	hpa, err := kubernetes.GenerateHorizontalPodAutoscaler(kind, serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "HPA_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate HorizontalPodAutoscaler for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "labels"),
+		})
+	} else if hpa != nil {
+		warnings = append(warnings, c.checkAutoscalingResources(serviceName, service, hpa)...)
+		add(hpa, "hpa", "hpas", "horizontalpodautoscaler")
+	}
+
+	pdb, err := kubernetes.GeneratePodDisruptionBudget(kind, serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "PDB_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate PodDisruptionBudget for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "labels"),
+		})
+	} else if pdb != nil {
+		add(pdb, "pdb", "pdbs", "poddisruptionbudget")
+	}
+
+	// Générer le Service headless d'un StatefulSet
+	if kind == kubernetes.WorkloadStatefulSet {
+		headlessService, err := kubernetes.GenerateHeadlessService(serviceName, serviceData, options)
+		if err != nil {
+			errors = append(errors, ConversionError{
+				Code:    "SERVICE_GENERATION_ERROR",
+				Message: fmt.Sprintf("Failed to generate headless service for %s: %v", serviceName, err),
+				Field:   docker.ServiceField(serviceName, "ports"),
+			})
+		} else {
+			add(headlessService, "headless-service", "services", "service")
+		}
+	}
+
+	// Générer le Service si nécessaire
+	kubernetesService, err := kubernetes.GenerateService(serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "SERVICE_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate service for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "ports"),
+		})
+	} else if kubernetesService != nil {
+		add(kubernetesService, "service", "services", "service")
+	}
+
+	// Générer l'Ingress si l'exposition HTTP est activée
+	ingress, err := kubernetes.GenerateIngress(serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "INGRESS_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate ingress for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "labels"),
+		})
+	} else if ingress != nil {
+		add(ingress, "ingress", "ingresses", "ingress")
+	}
+
+	// Générer la HTTPRoute en mode Gateway API
+	route, err := kubernetes.GenerateHTTPRoute(serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "HTTPROUTE_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate HTTPRoute for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "labels"),
+		})
+	} else if route != nil {
+		add(route, "httproute", "httproutes", "httproute")
+	}
+
+	// Générer la ConfigMap si nécessaire
+	configMap, err := kubernetes.GenerateConfigMap(serviceName, serviceData, options)
+	if err != nil {
+		warnings = append(warnings, ConversionWarning{
+			Code:    "CONFIGMAP_GENERATION_WARNING",
+			Message: fmt.Sprintf("Failed to generate configmap for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "environment"),
+		})
+	} else if configMap != nil {
+		add(configMap, "configmap", "configmaps", "configmap")
+	}
+
+	// Générer le Secret des variables sensibles si nécessaire
+	secret, err := kubernetes.GenerateSecret(serviceName, serviceData, options)
+	if err != nil {
+		errors = append(errors, ConversionError{
+			Code:    "SECRET_GENERATION_ERROR",
+			Message: fmt.Sprintf("Failed to generate secret for %s: %v", serviceName, err),
+			Field:   docker.ServiceField(serviceName, "environment"),
+		})
+	} else if secret != nil {
+		add(secret, "secret", "secrets", "secret")
+	}
+
+	// Ajouter des avertissements pour les fonctionnalités non supportées
+	warnings = append(warnings, c.checkUnsupportedFeatures(serviceName, service, options)...)
+	warnings = append(warnings, c.checkDependencies(serviceName, serviceData)...)
+	warnings = append(warnings, c.checkSecurity(serviceName, serviceData)...)
+
+	// Réglages contraires au niveau de durcissement, refusés à l'admission
+	hardeningErrors, hardeningWarnings := c.checkHardening(serviceName, serviceData, options)
+	errors = append(errors, hardeningErrors...)
+	warnings = append(warnings, hardeningWarnings...)
+
+	return manifests, errors, warnings
+}
+
+// serviceToMap convertit un service Docker en map pour le générateur
+func (c *DockerComposeToKubernetesConverter) serviceToMap(service docker.Service) map[string]interface{} {
+	// Conversion manuelle pour simplifier
+	result := make(map[string]interface{})
+
+	if service.Image != "" {
+		result["image"] = service.Image
+	}
+
+	if len(service.Ports) > 0 {
+		ports := make([]interface{}, len(service.Ports))
+		for i, port := range service.Ports {
+			portMap := map[string]interface{}{
+				"target":   port.Target,
+				"protocol": port.Protocol,
+			}
+			if published := port.PublishedPort(); published > 0 {
+				portMap["published"] = published
+			}
+			if port.HostIP != "" {
+				portMap["host_ip"] = port.HostIP
+			}
+			if port.Name != "" {
+				portMap["name"] = port.Name
+			}
+			if port.AppProtocol != "" {
+				portMap["app_protocol"] = port.AppProtocol
+			}
+			if port.Mode != "" {
+				portMap["mode"] = port.Mode
+			}
+			ports[i] = portMap
+		}
+		result["ports"] = ports
+	}
+
+	if service.Environment != nil {
+		result["environment"] = service.Environment
+	}
+
+	if len(service.Volumes) > 0 {
+		volumes := make([]interface{}, len(service.Volumes))
+		for i, volume := range service.Volumes {
+			volumeMap := map[string]interface{}{
+				"type":   volume.Type,
+				"target": volume.Target,
+			}
+			if volume.Source != "" {
+				volumeMap["source"] = volume.Source
+			}
+			if volume.ReadOnly {
+				volumeMap["read_only"] = true
+			}
+			if volume.Bind != nil && volume.Bind.Propagation != "" {
+				volumeMap["propagation"] = volume.Bind.Propagation
+			}
+			if volume.Volume != nil && volume.Volume.Subpath != "" {
+				volumeMap["subpath"] = volume.Volume.Subpath
+			}
+			if volume.Tmpfs != nil && volume.Tmpfs.Size != "" {
+				volumeMap["size"] = volume.Tmpfs.Size
+			}
+			volumes[i] = volumeMap
+		}
+		result["volumes"] = volumes
+	}
+
+	if len(service.Secrets) > 0 {
+		result["secrets"] = fileReferencesToMap(service.Secrets)
+	}
+
+	if len(service.Configs) > 0 {
+		result["configs"] = fileReferencesToMap(service.Configs)
 	}
 
 	if service.Command != nil {
@@ -417,20 +1239,48 @@ func (c *DockerComposeToKubernetesConverter) serviceToMap(service docker.Service
 		result["entrypoint"] = service.Entrypoint
 	}
 
-	if service.WorkingDir != "" {
-		result["working_dir"] = service.WorkingDir
+	if len(service.Labels) > 0 {
+		result["labels"] = map[string]string(service.Labels)
 	}
 
-	if service.User != "" {
-		result["user"] = service.User
+	if service.Restart != "" {
+		result["restart"] = service.Restart
 	}
 
-	if service.Privileged {
-		result["privileged"] = service.Privileged
+	if dependencies, ok := service.DependsOn.(map[string]docker.DependencyConfig); ok && len(dependencies) > 0 {
+		dependsOn := make(map[string]interface{})
+		for name, config := range dependencies {
+			dependsOn[name] = map[string]interface{}{"condition": config.Condition}
+		}
+		result["depends_on"] = dependsOn
 	}
 
-	if service.ReadOnly {
-		result["read_only"] = service.ReadOnly
+	if len(service.Links) > 0 {
+		links := make([]interface{}, len(service.Links))
+		for i, link := range service.Links {
+			links[i] = link
+		}
+		result["links"] = links
+	}
+
+	if networks, ok := service.Networks.(map[string]docker.NetworkConfig); ok && len(networks) > 0 {
+		networkMap := make(map[string]interface{})
+		for name, config := range networks {
+			aliases := make([]interface{}, len(config.Aliases))
+			for i, alias := range config.Aliases {
+				aliases[i] = alias
+			}
+			networkMap[name] = map[string]interface{}{"aliases": aliases}
+		}
+		result["networks"] = networkMap
+	}
+
+	if service.WorkingDir != "" {
+		result["working_dir"] = service.WorkingDir
+	}
+
+	for key, value := range securityToMap(service) {
+		result[key] = value
 	}
 
 	if service.HealthCheck != nil {
@@ -447,11 +1297,49 @@ func (c *DockerComposeToKubernetesConverter) serviceToMap(service docker.Service
 		if service.HealthCheck.Retries > 0 {
 			healthcheck["retries"] = service.HealthCheck.Retries
 		}
+		if service.HealthCheck.StartPeriod > 0 {
+			healthcheck["start_period"] = service.HealthCheck.StartPeriod.String()
+		}
+		if service.HealthCheck.StartInterval > 0 {
+			healthcheck["start_interval"] = service.HealthCheck.StartInterval.String()
+		}
+		if service.HealthCheck.Disable {
+			healthcheck["disable"] = true
+		}
 		result["healthcheck"] = healthcheck
 	}
 
 	if service.Deploy != nil {
 		deploy := make(map[string]interface{})
+		if service.Deploy.Mode != "" {
+			deploy["mode"] = service.Deploy.Mode
+		}
+		if service.Deploy.Replicas > 0 {
+			deploy["replicas"] = service.Deploy.Replicas
+		}
+		if service.Deploy.RestartPolicy != nil {
+			restartPolicy := make(map[string]interface{})
+			if service.Deploy.RestartPolicy.Condition != "" {
+				restartPolicy["condition"] = service.Deploy.RestartPolicy.Condition
+			}
+			if service.Deploy.RestartPolicy.MaxAttempts > 0 {
+				restartPolicy["max_attempts"] = service.Deploy.RestartPolicy.MaxAttempts
+			}
+			deploy["restart_policy"] = restartPolicy
+		}
+		if service.Deploy.UpdateConfig != nil {
+			updateConfig := make(map[string]interface{})
+			if service.Deploy.UpdateConfig.Parallelism > 0 {
+				updateConfig["parallelism"] = service.Deploy.UpdateConfig.Parallelism
+			}
+			if service.Deploy.UpdateConfig.Delay > 0 {
+				updateConfig["delay"] = service.Deploy.UpdateConfig.Delay.String()
+			}
+			if service.Deploy.UpdateConfig.Order != "" {
+				updateConfig["order"] = service.Deploy.UpdateConfig.Order
+			}
+			deploy["update_config"] = updateConfig
+		}
 		if service.Deploy.Resources != nil {
 			resources := make(map[string]interface{})
 			if service.Deploy.Resources.Limits != nil {
@@ -482,82 +1370,312 @@ func (c *DockerComposeToKubernetesConverter) serviceToMap(service docker.Service
 	return result
 }
 
-// convertVolumes convertit les volumes globaux
-func (c *DockerComposeToKubernetesConverter) convertVolumes(volumes map[string]docker.Volume, options kubernetes.GeneratorOptions) ([]GeneratedFile, []ConversionError) {
-	var files []GeneratedFile
-	var errors []ConversionError
+// fileReferencesToMap convertit les références secrets ou configs d'un service pour le générateur
+func fileReferencesToMap(references docker.FileReferenceList) []interface{} {
+	result := make([]interface{}, len(references))
+	for i, reference := range references {
+		referenceMap := map[string]interface{}{
+			"source": reference.Source,
+			"target": reference.Target,
+			"name":   reference.Name,
+		}
+		if reference.Mode != nil {
+			referenceMap["mode"] = int(*reference.Mode)
+		}
+		result[i] = referenceMap
+	}
+	return result
+}
 
-	for volumeName, volume := range volumes {
-		// Créer un PersistentVolume pour chaque volume nommé
-		pv := &kubernetes.PersistentVolume{
-			APIVersion: "v1",
-			Kind:       "PersistentVolume",
-			Metadata: kubernetes.Metadata{
-				Name:   volumeName,
-				Labels: options.Labels,
-			},
-			Spec: kubernetes.PersistentVolumeSpec{
-				Capacity: map[string]string{
-					"storage": "1Gi", // Taille par défaut
-				},
-				AccessModes:                   []string{"ReadWriteOnce"},
-				PersistentVolumeReclaimPolicy: "Retain",
-				HostPath: &kubernetes.HostPathVolumeSource{
-					Path: fmt.Sprintf("/mnt/data/%s", volumeName),
-				},
-			},
+// Valeur écrite dans les Secrets et ConfigMaps dont le contenu n'a pas été fourni
+const missingContentPlaceholder = "CHANGE_ME"
+
+// convertFileResources génère les Secrets et ConfigMaps des secrets et configs de premier niveau.
+// Les ressources externes sont supposées exister dans le cluster et ne sont pas générées.
+func (c *DockerComposeToKubernetesConverter) convertFileResources(dockerCompose *docker.DockerCompose, options kubernetes.GeneratorOptions) ([]manifest, []ConversionWarning) {
+	var manifests []manifest
+	var warnings []ConversionWarning
+
+	add := func(object kubernetes.KubernetesObject, field string) {
+		kind := strings.ToLower(object.GetKind())
+		m := projectManifest(object, kind, kind+"s", kind)
+		m.field = field
+		manifests = append(manifests, m)
+	}
+
+	missing := func(kind, name string, file string) ConversionWarning {
+		suggestion := fmt.Sprintf("Replace the %s placeholder in the generated manifest", missingContentPlaceholder)
+		if file != "" {
+			suggestion = fmt.Sprintf("Upload %s with the request bundle, or replace the %s placeholder in the generated manifest", file, missingContentPlaceholder)
 		}
+		return ConversionWarning{
+			Code:       strings.ToUpper(kind) + "_CONTENT_MISSING",
+			Message:    fmt.Sprintf("Content of %s %s was not provided; a placeholder value was generated", kind, name),
+			Suggestion: suggestion,
+			Field:      docker.FieldPointer(kind+"s", name),
+		}
+	}
 
-		// Override avec les options du volume si disponibles
-		if volume.Driver != "" && volume.Driver != "local" {
-			errors = append(errors, ConversionError{
-				Code:    "UNSUPPORTED_VOLUME_DRIVER",
-				Message: fmt.Sprintf("Volume driver '%s' is not supported for volume '%s'", volume.Driver, volumeName),
-			})
+	for _, name := range sortedMapKeys(dockerCompose.Secrets) {
+		secret := dockerCompose.Secrets[name]
+		if docker.IsExternal(secret.External) {
 			continue
 		}
+		data := secret.Data
+		if !secret.Resolved {
+			data = missingContentPlaceholder
+			warnings = append(warnings, missing("secret", name, secret.File))
+		}
+		add(kubernetes.GenerateFileSecret(name, docker.ResourceName(name, secret.Name, secret.External), data, options), docker.FieldPointer("secrets", name))
+	}
 
-		pvYAML, err := yaml.Marshal(pv)
-		if err != nil {
-			errors = append(errors, ConversionError{
-				Code:    "YAML_MARSHAL_ERROR",
-				Message: fmt.Sprintf("Failed to marshal PersistentVolume for volume %s: %v", volumeName, err),
-			})
+	for _, name := range sortedMapKeys(dockerCompose.Configs) {
+		config := dockerCompose.Configs[name]
+		if docker.IsExternal(config.External) {
 			continue
 		}
+		data := config.Data
+		if !config.Resolved {
+			data = missingContentPlaceholder
+			warnings = append(warnings, missing("config", name, config.File))
+		}
+		add(kubernetes.GenerateFileConfigMap(name, docker.ResourceName(name, config.Name, config.External), data, options), docker.FieldPointer("configs", name))
+	}
 
-		files = append(files, GeneratedFile{
-			Name:    fmt.Sprintf("%s-pv.yaml", volumeName),
-			Content: string(pvYAML),
-			Type:    "persistentvolume",
-			Path:    fmt.Sprintf("volumes/%s-pv.yaml", volumeName),
+	return manifests, warnings
+}
+
+// sortedMapKeys retourne les clés d'une map triées
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkAutoscalingResources signale un HPA basé sur l'utilisation d'une ressource sans requête ni limite :
+// Kubernetes ne peut pas calculer le pourcentage d'utilisation sans requête sur le conteneur
+func (c *DockerComposeToKubernetesConverter) checkAutoscalingResources(serviceName string, service docker.Service, hpa *kubernetes.HorizontalPodAutoscaler) []ConversionWarning {
+	var warnings []ConversionWarning
+
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Resource == nil {
+			continue
+		}
+		configured := false
+		if service.Deploy != nil && service.Deploy.Resources != nil {
+			for _, resources := range []*docker.ResourceLimits{service.Deploy.Resources.Limits, service.Deploy.Resources.Reservations} {
+				if resources == nil {
+					continue
+				}
+				if (metric.Resource.Name == "cpu" && resources.CPUs != "") || (metric.Resource.Name == "memory" && resources.Memory != "") {
+					configured = true
+				}
+			}
+		}
+		if !configured {
+			warnings = append(warnings, ConversionWarning{
+				Code:    "HPA_WITHOUT_RESOURCE_REQUEST",
+				Message: fmt.Sprintf("Service %s is autoscaled on %s utilization but declares no %s reservation or limit", serviceName, metric.Resource.Name, metric.Resource.Name),
+				Field:   docker.ServiceField(serviceName),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// isCommandReset indique si une commande ou un entrypoint normalisé est une liste vide (command: [], entrypoint: "")
+func isCommandReset(value interface{}) bool {
+	list, ok := value.([]string)
+	return ok && len(list) == 0
+}
+
+// hasCommandArgs indique si une commande normalisée contient au moins un argument
+func hasCommandArgs(value interface{}) bool {
+	list, ok := value.([]string)
+	return ok && len(list) > 0
+}
+
+// checkDependencies signale les dépendances qu'aucun init container ne peut attendre
+func (c *DockerComposeToKubernetesConverter) checkDependencies(serviceName string, serviceData map[string]interface{}) []ConversionWarning {
+	var warnings []ConversionWarning
+
+	_, unresolved := kubernetes.ResolveDependencyWaits(serviceData)
+	for _, dependency := range unresolved {
+		warnings = append(warnings, ConversionWarning{
+			Code:       "DEPENDENCY_NOT_AWAITED",
+			Message:    fmt.Sprintf("Service %s will not wait for its dependency %s: %s", serviceName, dependency.Service, dependency.Reason),
+			Suggestion: "Declare the port of the dependency, or make the service retry its connections on startup",
+			Field:      docker.ServiceField(serviceName, "depends_on", dependency.Service),
 		})
 	}
 
-	return files, errors
+	return warnings
+}
+
+// securityToMap extrait les réglages de sécurité du service au format attendu par le générateur
+func securityToMap(service docker.Service) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	if service.User != "" {
+		result["user"] = service.User
+	}
+
+	if service.Privileged {
+		result["privileged"] = service.Privileged
+	}
+
+	if service.ReadOnly {
+		result["read_only"] = service.ReadOnly
+	}
+
+	for key, values := range map[string][]string{
+		"cap_add":      service.CapAdd,
+		"cap_drop":     service.CapDrop,
+		"security_opt": service.SecurityOpt,
+		"group_add":    service.GroupAdd,
+	} {
+		if len(values) > 0 {
+			items := make([]interface{}, len(values))
+			for i, value := range values {
+				items[i] = value
+			}
+			result[key] = items
+		}
+	}
+
+	if sysctls, ok := service.Sysctls.(map[string]string); ok && len(sysctls) > 0 {
+		sysctlMap := make(map[string]interface{}, len(sysctls))
+		for name, value := range sysctls {
+			sysctlMap[name] = value
+		}
+		result["sysctls"] = sysctlMap
+	}
+
+	if service.UsernsMode != "" {
+		result["userns_mode"] = service.UsernsMode
+	}
+
+	return result
+}
+
+// checkSecurity signale les réglages de sécurité que Kubernetes ne peut pas exprimer fidèlement
+func (c *DockerComposeToKubernetesConverter) checkSecurity(serviceName string, serviceData map[string]interface{}) []ConversionWarning {
+	var warnings []ConversionWarning
+
+	_, _, issues := kubernetes.ResolveSecurity(serviceData)
+	for _, issue := range issues {
+		warnings = append(warnings, ConversionWarning{
+			Code:       "UNSUPPORTED_SECURITY_SETTING",
+			Message:    fmt.Sprintf("Security setting of service %s is not fully converted: %s", serviceName, issue.Reason),
+			Suggestion: "Use numeric IDs and namespaced sysctls, or configure the nodes accordingly",
+			Field:      docker.ServiceField(serviceName, issue.Path...),
+		})
+	}
+
+	return warnings
+}
+
+// checkHardening signale les réglages du service incompatibles avec le niveau de durcissement
+func (c *DockerComposeToKubernetesConverter) checkHardening(serviceName string, serviceData map[string]interface{}, options kubernetes.GeneratorOptions) ([]ConversionError, []ConversionWarning) {
+	var errors []ConversionError
+	var warnings []ConversionWarning
+
+	level := options.Hardening.Level
+	for _, violation := range kubernetes.CheckHardening(serviceData, level) {
+		errors = append(errors, ConversionError{
+			Code:    "HARDENING_VIOLATION",
+			Message: fmt.Sprintf("Service %s does not meet the %s Pod Security Standard: %s", serviceName, level, violation.Reason),
+			Field:   docker.ServiceField(serviceName, violation.Path...),
+		})
+	}
+
+	// runAsNonRoot ne peut être vérifié qu'au démarrage lorsque l'utilisateur n'est pas numérique
+	if level == kubernetes.HardeningRestricted {
+		if container, _, _ := kubernetes.ResolveSecurity(serviceData); container == nil || container.RunAsUser == nil {
+			warnings = append(warnings, ConversionWarning{
+				Code:       "HARDENING_USER_NOT_SET",
+				Message:    fmt.Sprintf("Service %s has no numeric user; its pods will only start if the image runs as a numeric non-root user", serviceName),
+				Suggestion: "Set user to a numeric non-root UID, for example user: \"1000:1000\"",
+				Field:      docker.ServiceField(serviceName),
+			})
+		}
+	}
+
+	return errors, warnings
 }
 
 // checkUnsupportedFeatures vérifie les fonctionnalités non supportées
-func (c *DockerComposeToKubernetesConverter) checkUnsupportedFeatures(serviceName string, service docker.Service) []ConversionWarning {
+func (c *DockerComposeToKubernetesConverter) checkUnsupportedFeatures(serviceName string, service docker.Service, options kubernetes.GeneratorOptions) []ConversionWarning {
 	var warnings []ConversionWarning
 
-	// Networks personnalisés
-	if service.Networks != nil {
+	// Networks personnalisés, isolés uniquement avec l'option networkPolicies
+	if service.Networks != nil && !options.NetworkPolicies {
 		if networks, ok := service.Networks.(map[string]docker.NetworkConfig); ok && len(networks) > 0 {
 			warnings = append(warnings, ConversionWarning{
 				Code:    "UNSUPPORTED_NETWORKS",
 				Message: fmt.Sprintf("Custom networks for service %s will be converted to default Kubernetes networking", serviceName),
+				Field:   docker.ServiceField(serviceName, "networks"),
 			})
 		}
 	}
 
-	// Dependencies
-	if service.DependsOn != nil {
-		if deps, ok := service.DependsOn.(map[string]docker.DependencyConfig); ok && len(deps) > 0 {
+	// Réinitialisations de l'image sans équivalent Kubernetes
+	if isCommandReset(service.Entrypoint) && !hasCommandArgs(service.Command) {
+		warnings = append(warnings, ConversionWarning{
+			Code:       "ENTRYPOINT_RESET_WITHOUT_COMMAND",
+			Message:    fmt.Sprintf("Service %s resets the image entrypoint without a command; Kubernetes will run the image defaults", serviceName),
+			Suggestion: "Set command to the program the container should run",
+			Field:      docker.ServiceField(serviceName, "entrypoint"),
+		})
+	}
+	if isCommandReset(service.Command) && service.Entrypoint == nil {
+		warnings = append(warnings, ConversionWarning{
+			Code:       "COMMAND_RESET_NOT_SUPPORTED",
+			Message:    fmt.Sprintf("Service %s clears the image command, which Kubernetes cannot express without an entrypoint; the image command will still run", serviceName),
+			Suggestion: "Set entrypoint to the image entrypoint explicitly",
+			Field:      docker.ServiceField(serviceName, "command"),
+		})
+	}
+
+	// Montages sans équivalent direct
+	for i, volume := range service.Volumes {
+		field := docker.ServiceField(serviceName, "volumes", strconv.Itoa(i))
+		switch {
+		case volume.Type == docker.VolumeTypeNpipe:
+			warnings = append(warnings, ConversionWarning{
+				Code:    "UNSUPPORTED_VOLUME_TYPE",
+				Message: fmt.Sprintf("Named pipe mount %s for service %s is not supported and was skipped", volume.Target, serviceName),
+				Field:   field,
+			})
+		case volume.Type == docker.VolumeTypeBind && !strings.HasPrefix(volume.Source, "/"):
+			warnings = append(warnings, ConversionWarning{
+				Code:       "RELATIVE_BIND_MOUNT",
+				Message:    fmt.Sprintf("Bind mount source %s for service %s is not an absolute path and cannot be used as a hostPath", volume.Source, serviceName),
+				Suggestion: "Use a ConfigMap or a named volume, or replace the source with an absolute node path",
+				Field:      field,
+			})
+		}
+	}
+
+	// Propriétaire des secrets et configs montés
+	for _, kind := range []string{"secrets", "configs"} {
+		references := service.Secrets
+		if kind == "configs" {
+			references = service.Configs
+		}
+		for i, reference := range references {
+			if reference.UID == "" && reference.GID == "" {
+				continue
+			}
 			warnings = append(warnings, ConversionWarning{
-				Code:       "UNSUPPORTED_DEPENDS_ON",
-				Message:    fmt.Sprintf("Dependencies for service %s are not directly supported in Kubernetes", serviceName),
-				Suggestion: "Consider using init containers or readiness probes",
+				Code:       "UNSUPPORTED_FILE_OWNERSHIP",
+				Message:    fmt.Sprintf("uid and gid of %s %s for service %s cannot be set on a mounted file", strings.TrimSuffix(kind, "s"), reference.Source, serviceName),
+				Suggestion: "Set securityContext.fsGroup on the pod so that the group can read the file",
+				Field:      docker.ServiceField(serviceName, kind, strconv.Itoa(i)),
 			})
 		}
 	}
@@ -567,6 +1685,7 @@ func (c *DockerComposeToKubernetesConverter) checkUnsupportedFeatures(serviceNam
 		warnings = append(warnings, ConversionWarning{
 			Code:    "UNSUPPORTED_PID_MODE",
 			Message: fmt.Sprintf("PID mode '%s' for service %s is not supported", service.PidMode, serviceName),
+			Field:   docker.ServiceField(serviceName, "pid"),
 		})
 	}
 
@@ -575,6 +1694,7 @@ func (c *DockerComposeToKubernetesConverter) checkUnsupportedFeatures(serviceNam
 		warnings = append(warnings, ConversionWarning{
 			Code:    "UNSUPPORTED_IPC_MODE",
 			Message: fmt.Sprintf("IPC mode '%s' for service %s is not supported", service.IpcMode, serviceName),
+			Field:   docker.ServiceField(serviceName, "ipc"),
 		})
 	}
 
@@ -583,97 +1703,28 @@ func (c *DockerComposeToKubernetesConverter) checkUnsupportedFeatures(serviceNam
 		warnings = append(warnings, ConversionWarning{
 			Code:    "UNSUPPORTED_SHM_SIZE",
 			Message: fmt.Sprintf("SHM size configuration for service %s requires manual setup in Kubernetes", serviceName),
+			Field:   docker.ServiceField(serviceName, "shm_size"),
 		})
 	}
 
 	return warnings
 }
 
-// convertServiceToObjects convertit un service en objets Kubernetes
-func (c *DockerComposeToKubernetesConverter) convertServiceToObjects(serviceName string, service docker.Service, options kubernetes.GeneratorOptions) ([]kubernetes.KubernetesObject, []ConversionError, []ConversionWarning) {
-	var objects []kubernetes.KubernetesObject
+// checkVolumes vérifie les volumes globaux : seuls les volumes du driver local deviennent des PVCs
+// provisionnés par la classe de stockage par défaut
+func (c *DockerComposeToKubernetesConverter) checkVolumes(volumes map[string]docker.Volume) []ConversionError {
 	var errors []ConversionError
-	var warnings []ConversionWarning
 
-	// Conversion du service en map[string]interface{} pour le générateur
-	serviceData := c.serviceToMap(service)
-
-	// Générer le Deployment
-	deployment, err := kubernetes.GenerateDeployment(serviceName, serviceData, options)
-	if err != nil {
-		errors = append(errors, ConversionError{
-			Code:    "DEPLOYMENT_GENERATION_ERROR",
-			Message: fmt.Sprintf("Failed to generate deployment for service %s: %v", serviceName, err),
-		})
-	} else {
-		objects = append(objects, deployment)
-	}
-
-	// Générer le Service si nécessaire
-	kubernetesService, err := kubernetes.GenerateService(serviceName, serviceData, options)
-	if err != nil {
-		errors = append(errors, ConversionError{
-			Code:    "SERVICE_GENERATION_ERROR",
-			Message: fmt.Sprintf("Failed to generate service for %s: %v", serviceName, err),
-		})
-	} else if kubernetesService != nil {
-		objects = append(objects, kubernetesService)
-	}
-
-	// Générer la ConfigMap si nécessaire
-	configMap, err := kubernetes.GenerateConfigMap(serviceName, serviceData, options)
-	if err != nil {
-		warnings = append(warnings, ConversionWarning{
-			Code:    "CONFIGMAP_GENERATION_WARNING",
-			Message: fmt.Sprintf("Failed to generate configmap for %s: %v", serviceName, err),
-		})
-	} else if configMap != nil {
-		objects = append(objects, configMap)
-	}
-
-	// Ajouter des avertissements pour les fonctionnalités non supportées
-	warnings = append(warnings, c.checkUnsupportedFeatures(serviceName, service)...)
-
-	return objects, errors, warnings
-}
-
-// convertVolumesToObjects convertit les volumes en objets Kubernetes
-func (c *DockerComposeToKubernetesConverter) convertVolumesToObjects(volumes map[string]docker.Volume, options kubernetes.GeneratorOptions) ([]kubernetes.KubernetesObject, []ConversionError) {
-	var objects []kubernetes.KubernetesObject
-	var errors []ConversionError
-
-	for volumeName, volume := range volumes {
-		// Créer un PersistentVolume pour chaque volume nommé
-		pv := &kubernetes.PersistentVolume{
-			APIVersion: "v1",
-			Kind:       "PersistentVolume",
-			Metadata: kubernetes.Metadata{
-				Name:   volumeName,
-				Labels: options.Labels,
-			},
-			Spec: kubernetes.PersistentVolumeSpec{
-				Capacity: map[string]string{
-					"storage": "1Gi", // Taille par défaut
-				},
-				AccessModes:                   []string{"ReadWriteOnce"},
-				PersistentVolumeReclaimPolicy: "Retain",
-				HostPath: &kubernetes.HostPathVolumeSource{
-					Path: fmt.Sprintf("/mnt/data/%s", volumeName),
-				},
-			},
-		}
-
-		// Override avec les options du volume si disponibles
+	for _, volumeName := range sortedMapKeys(volumes) {
+		volume := volumes[volumeName]
 		if volume.Driver != "" && volume.Driver != "local" {
 			errors = append(errors, ConversionError{
 				Code:    "UNSUPPORTED_VOLUME_DRIVER",
 				Message: fmt.Sprintf("Volume driver '%s' is not supported for volume '%s'", volume.Driver, volumeName),
+				Field:   docker.FieldPointer("volumes", volumeName, "driver"),
 			})
-			continue
 		}
-
-		objects = append(objects, pv)
 	}
 
-	return objects, errors
+	return errors
 }
@@ -0,0 +1,95 @@
+package converters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDuplicateObjectNames(t *testing.T) {
+	converter := NewDockerComposeToKubernetesConverter()
+	content := `services:
+  db:
+    image: postgres
+    environment:
+      DB_PASSWORD: secret
+      MODE: primary
+    secrets: [db-secret]
+    configs: [db-config]
+secrets:
+  db-secret:
+    environment: DB_PASSWORD
+configs:
+  db-config:
+    content: hello
+`
+
+	result, err := converter.Convert(context.Background(), ConversionRequest{
+		Type:    "docker-compose",
+		Content: content,
+		Options: map[string]interface{}{"allInOne": false},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+
+	// Le Secret et la ConfigMap de premier niveau reprennent les noms des objets du service db
+	var fields []string
+	for _, conversionError := range result.Errors {
+		assert.Equal(t, "DUPLICATE_OBJECT_NAME", conversionError.Code)
+		fields = append(fields, conversionError.Field)
+	}
+	assert.ElementsMatch(t, []string{"/secrets/db-secret", "/configs/db-config"}, fields)
+
+	// Un nom de ressource distinct supprime le conflit
+	result, err = converter.Convert(context.Background(), ConversionRequest{
+		Type:    "docker-compose",
+		Content: content + "    name: db-settings\n",
+		Options: map[string]interface{}{"allInOne": false},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "/secrets/db-secret", result.Errors[0].Field)
+}
+
+func TestConvertHardeningScratchPaths(t *testing.T) {
+	converter := NewDockerComposeToKubernetesConverter()
+	convert := func(scratchPaths ...interface{}) *ConversionResult {
+		result, err := converter.Convert(context.Background(), ConversionRequest{
+			Type:    "docker-compose",
+			Content: "services:\n  web:\n    image: nginx\n    user: \"101\"\n",
+			Options: map[string]interface{}{"allInOne": false, "hardening": "restricted", "hardeningScratchPaths": scratchPaths},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// Les chemins sont normalisés avant la génération
+	result := convert("/var/cache/", "/var/cache")
+	assert.True(t, result.Success)
+	assert.Empty(t, result.Errors)
+
+	result = convert("/tmp", "/")
+	assert.False(t, result.Success)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "INVALID_HARDENING_SCRATCH_PATH", result.Errors[0].Code)
+}
+
+func TestConvertServiceObjectFileNames(t *testing.T) {
+	converter := NewDockerComposeToKubernetesConverter()
+	result, err := converter.Convert(context.Background(), ConversionRequest{
+		Type:    "docker-compose",
+		Content: "services:\n  My_App:\n    image: app\n    environment:\n      MODE: primary\n      DB_PASSWORD: secret\n",
+		Options: map[string]interface{}{"allInOne": false},
+	})
+	require.NoError(t, err)
+
+	// Le fichier porte le même nom normalisé que l'objet
+	var paths []string
+	for _, file := range result.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.Contains(t, paths, "configmaps/my-app-configmap.yaml")
+	assert.Contains(t, paths, "secrets/my-app-secret.yaml")
+}
@@ -1,13 +1,25 @@
 package converters
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+)
 
 // ConversionRequest représente une demande de conversion
 type ConversionRequest struct {
 	Type      string                 `json:"type" binding:"required"`      // docker-compose, dockerfile, etc.
-	Content   string                 `json:"content" binding:"required"`   // Contenu du fichier
+	Content   string                 `json:"content"`                      // Contenu du fichier
 	Options   map[string]interface{} `json:"options,omitempty"`            // Options de conversion
 	Filename  string                 `json:"filename,omitempty"`           // Nom du fichier original
+	Documents []SourceFile           `json:"documents,omitempty"`          // Fichiers d'override appliqués dans l'ordre après Content
+	Bundle    []SourceFile           `json:"bundle,omitempty"`             // Fichiers référencés par extends/include, nommés par chemin relatif
+}
+
+// SourceFile représente un fichier source nommé fourni avec la requête
+type SourceFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
 }
 
 // ConversionResult représente le résultat d'une conversion
@@ -31,17 +43,36 @@ type GeneratedFile struct {
 type ConversionError struct {
 	Code        string `json:"code"`
 	Message     string `json:"message"`
+	File        string `json:"file,omitempty"`
 	Line        int    `json:"line,omitempty"`
 	Column      int    `json:"column,omitempty"`
 	Field       string `json:"field,omitempty"`
 	Suggestion  string `json:"suggestion,omitempty"`
 }
 
+// ValidationErrors regroupe les erreurs structurées retournées par Validate
+type ValidationErrors []ConversionError
+
+// Error implémente l'interface error
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		if err.Line > 0 {
+			messages[i] = fmt.Sprintf("line %d: %s", err.Line, err.Message)
+			continue
+		}
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
 // ConversionWarning représente un avertissement de conversion
 type ConversionWarning struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
+	File       string `json:"file,omitempty"`
 	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
 	Field      string `json:"field,omitempty"`
 	Suggestion string `json:"suggestion,omitempty"`
 }
@@ -64,6 +95,12 @@ type Converter interface {
 	GetDescription() string
 }
 
+// RequestValidator convertisseur capable de valider une requête complète (options, overrides et
+// bundle), avec les mêmes entrées que Convert
+type RequestValidator interface {
+	ValidateRequest(ctx context.Context, req ConversionRequest) error
+}
+
 // ConverterRegistry interface pour le registre des convertisseurs
 type ConverterRegistry interface {
 	// Register enregistre un nouveau convertisseur
@@ -0,0 +1,158 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AliasConflict signale un alias réseau ou un alias de lien qui ne peut pas devenir un Service
+type AliasConflict struct {
+	Alias string
+	// Service service qui déclare l'alias
+	Service string
+	// Path chemin de la déclaration dans le service (networks/<réseau>/aliases ou links/<index>)
+	Path   []string
+	Reason string
+	// Unsupported alias sans équivalent Kubernetes (nom DNS invalide) : il est ignoré sans bloquer la conversion
+	Unsupported bool
+}
+
+// Error implémente l'interface error
+func (c AliasConflict) Error() string {
+	return fmt.Sprintf("alias %s declared by service %s: %s", c.Alias, c.Service, c.Reason)
+}
+
+// serviceAlias nom DNS supplémentaire pointant vers les pods d'un service
+type serviceAlias struct {
+	name    string
+	target  string
+	service string
+	path    []string
+}
+
+// GenerateAliasServices génère un Service supplémentaire pour chaque alias réseau
+// (networks.<réseau>.aliases) et chaque alias de lien (links: service:alias).
+// Le Service porte le nom de l'alias et sélectionne les pods du service ciblé, avec les mêmes ports.
+// Les alias en conflit avec un autre nom sont retournés comme conflits ; ceux qui ne sont pas des
+// noms DNS valides (api.local) sont ignorés et retournés comme conflits Unsupported.
+func GenerateAliasServices(services map[string]interface{}, options GeneratorOptions) ([]*Service, []AliasConflict) {
+	serviceNames := make([]string, 0, len(services))
+	for name := range services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	var aliases []serviceAlias
+	for _, name := range serviceNames {
+		serviceMap, _ := services[name].(map[string]interface{})
+
+		networks, _ := serviceMap["networks"].(map[string]interface{})
+		networkNames := make([]string, 0, len(networks))
+		for network := range networks {
+			networkNames = append(networkNames, network)
+		}
+		sort.Strings(networkNames)
+		for _, network := range networkNames {
+			config, _ := networks[network].(map[string]interface{})
+			for i, alias := range normalizeStringSlice(config["aliases"]) {
+				aliases = append(aliases, serviceAlias{
+					name:    alias,
+					target:  name,
+					service: name,
+					path:    []string{"networks", network, "aliases", strconv.Itoa(i)},
+				})
+			}
+		}
+
+		// Un lien "db:database" rend le service db joignable sous le nom database
+		links, _ := serviceMap["links"].([]interface{})
+		for i, link := range links {
+			target, alias, ok := strings.Cut(stringValue(link), ":")
+			if !ok {
+				alias = target
+			}
+			aliases = append(aliases, serviceAlias{
+				name:    strings.TrimSpace(alias),
+				target:  strings.TrimSpace(target),
+				service: name,
+				path:    []string{"links", strconv.Itoa(i)},
+			})
+		}
+	}
+
+	var generated []*Service
+	var conflicts []AliasConflict
+	owners := make(map[string]string)
+
+	for _, alias := range aliases {
+		conflict := func(reason string) {
+			conflicts = append(conflicts, AliasConflict{Alias: alias.name, Service: alias.service, Path: alias.path, Reason: reason})
+		}
+		unsupported := func(reason string) {
+			conflicts = append(conflicts, AliasConflict{Alias: alias.name, Service: alias.service, Path: alias.path, Reason: reason, Unsupported: true})
+		}
+
+		targetMap, ok := services[alias.target].(map[string]interface{})
+		switch {
+		case !ok:
+			conflict(fmt.Sprintf("service %s is not converted", alias.target))
+			continue
+		case alias.name == alias.target:
+			// Le Service principal porte déjà ce nom
+			continue
+		case alias.name == "" || toDNSLabel(alias.name) != alias.name || alias.name[0] < 'a' || alias.name[0] > 'z':
+			unsupported("not a valid Kubernetes Service name (lowercase letters, digits and '-', starting with a letter)")
+			continue
+		}
+
+		if _, ok := services[alias.name]; ok {
+			conflict(fmt.Sprintf("conflicts with service %s", alias.name))
+			continue
+		}
+		if statefulService, ok := strings.CutSuffix(alias.name, "-headless"); ok && services[statefulService] != nil {
+			conflict(fmt.Sprintf("conflicts with the headless Service of %s", statefulService))
+			continue
+		}
+		if owner, ok := owners[alias.name]; ok {
+			if owner != alias.target {
+				conflict(fmt.Sprintf("already used as an alias of service %s", owner))
+			}
+			continue
+		}
+		owners[alias.name] = alias.target
+
+		aliasService := &Service{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Metadata: Metadata{
+				Name:      alias.name,
+				Namespace: options.Namespace,
+				Labels:    mergeLabels(options.Labels, map[string]string{"app": alias.target}),
+			},
+			Spec: ServiceSpec{
+				Type:     "ClusterIP",
+				Selector: map[string]string{"app": alias.target},
+			},
+		}
+
+		ports, ok := targetMap["ports"].([]interface{})
+		if !ok || len(ports) == 0 {
+			// Sans port, le service ciblé n'a pas de Service : un Service headless fournit le nom DNS
+			aliasService.Spec.Type = ""
+			aliasService.Spec.ClusterIP = "None"
+		} else {
+			servicePorts, err := generateServicePorts(ports)
+			if err != nil {
+				conflict(fmt.Sprintf("failed to generate ports of service %s: %v", alias.target, err))
+				continue
+			}
+			aliasService.Spec.Ports = servicePorts
+		}
+
+		generated = append(generated, aliasService)
+	}
+
+	return generated, conflicts
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAliasServices(t *testing.T) {
+	services := map[string]interface{}{
+		"db": map[string]interface{}{
+			"image": "postgres",
+			"ports": []interface{}{"5432"},
+			"networks": map[string]interface{}{
+				"back": map[string]interface{}{"aliases": []interface{}{"database", "postgres"}},
+			},
+		},
+		"web": map[string]interface{}{
+			"image": "nginx",
+			"links": []interface{}{"db:database", "db", "cache:redis"},
+		},
+		"cache": map[string]interface{}{"image": "redis"},
+	}
+
+	generated, conflicts := GenerateAliasServices(services, DefaultGeneratorOptions())
+	assert.Empty(t, conflicts)
+
+	require.Len(t, generated, 3)
+	names := []string{generated[0].GetName(), generated[1].GetName(), generated[2].GetName()}
+	assert.Equal(t, []string{"database", "postgres", "redis"}, names)
+
+	database := generated[0]
+	assert.Equal(t, map[string]string{"app": "db"}, database.Spec.Selector)
+	require.Len(t, database.Spec.Ports, 1)
+	assert.Equal(t, int32(5432), database.Spec.Ports[0].Port)
+
+	// Sans port, l'alias est un Service headless
+	redis := generated[2]
+	assert.Equal(t, "None", redis.Spec.ClusterIP)
+	assert.Equal(t, map[string]string{"app": "cache"}, redis.Spec.Selector)
+}
+
+func TestGenerateAliasServicesConflicts(t *testing.T) {
+	services := map[string]interface{}{
+		"api": map[string]interface{}{
+			"image": "api",
+			"networks": map[string]interface{}{
+				"front": map[string]interface{}{"aliases": []interface{}{"backend", "web", "My_API", "api.local"}},
+			},
+		},
+		"worker": map[string]interface{}{
+			"image": "worker",
+			"links": []interface{}{"api:backend", "queue:broker"},
+			"networks": map[string]interface{}{
+				"front": map[string]interface{}{"aliases": []interface{}{"backend"}},
+			},
+		},
+		"web": map[string]interface{}{"image": "nginx"},
+	}
+
+	generated, conflicts := GenerateAliasServices(services, DefaultGeneratorOptions())
+	require.Len(t, generated, 1)
+	assert.Equal(t, "backend", generated[0].GetName())
+
+	reasons := make(map[string]AliasConflict)
+	for _, conflict := range conflicts {
+		reasons[conflict.Alias+"@"+conflict.Service] = conflict
+	}
+	require.Len(t, reasons, 5)
+	assert.Contains(t, reasons["web@api"].Reason, "conflicts with service web")
+	assert.False(t, reasons["web@api"].Unsupported)
+	assert.Contains(t, reasons["My_API@api"].Reason, "not a valid Kubernetes Service name")
+	assert.True(t, reasons["My_API@api"].Unsupported)
+	assert.True(t, reasons["api.local@api"].Unsupported)
+	assert.False(t, reasons["backend@worker"].Unsupported)
+	assert.Equal(t, []string{"networks", "front", "aliases", "0"}, reasons["backend@worker"].Path)
+	assert.Contains(t, reasons["backend@worker"].Reason, "alias of service api")
+	assert.Equal(t, []string{"links", "1"}, reasons["broker@worker"].Path)
+}
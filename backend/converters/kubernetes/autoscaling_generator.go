@@ -0,0 +1,280 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Labels docker-compose surchargeant l'autoscaling et le budget de disruption d'un service
+const (
+	AutoscalingLabelPrefix      = "devops-converter.hpa."
+	DisruptionBudgetLabelPrefix = "devops-converter.pdb."
+)
+
+// Valeurs dérivées lorsqu'aucune cible n'est configurée
+const (
+	defaultTargetUtilization = 80
+	defaultMaxReplicasFactor = 3
+	defaultPodMaxUnavailable = "1"
+)
+
+// AutoscalingTargets bornes et cibles d'utilisation d'un HPA (0 = non défini)
+type AutoscalingTargets struct {
+	MinReplicas  int32 `json:"minReplicas"`
+	MaxReplicas  int32 `json:"maxReplicas"`
+	TargetCPU    int32 `json:"targetCPU"`
+	TargetMemory int32 `json:"targetMemory"`
+}
+
+// AutoscalingOptions options de génération des HorizontalPodAutoscalers
+type AutoscalingOptions struct {
+	Enabled  bool                          `json:"enabled"`
+	Defaults AutoscalingTargets            `json:"defaults"`
+	Services map[string]AutoscalingTargets `json:"services"`
+}
+
+// DisruptionBudget minAvailable ou maxUnavailable d'un PDB (entier ou pourcentage)
+type DisruptionBudget struct {
+	MinAvailable   string `json:"minAvailable"`
+	MaxUnavailable string `json:"maxUnavailable"`
+}
+
+// DisruptionBudgetOptions options de génération des PodDisruptionBudgets
+type DisruptionBudgetOptions struct {
+	Enabled  bool                        `json:"enabled"`
+	Defaults DisruptionBudget            `json:"defaults"`
+	Services map[string]DisruptionBudget `json:"services"`
+}
+
+var disruptionBudgetValue = regexp.MustCompile(`^(\d+|\d+%)$`)
+
+// GenerateHorizontalPodAutoscaler génère le HPA d'un Deployment ou d'un StatefulSet.
+// Priorité des cibles : labels du service, options du service, options globales, puis valeurs
+// dérivées de deploy.replicas et des ressources (80% d'utilisation des ressources limitées).
+func GenerateHorizontalPodAutoscaler(kind, serviceName string, service interface{}, options GeneratorOptions) (*HorizontalPodAutoscaler, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	labels, _ := serviceMap["labels"].(map[string]string)
+	override, hasOverride := options.Autoscaling.Services[serviceName]
+	if !isScalableWorkload(kind) || !labelEnabled(labels, AutoscalingLabelPrefix, options.Autoscaling.Enabled || hasOverride) {
+		return nil, nil
+	}
+
+	targets := mergeAutoscalingTargets(options.Autoscaling.Defaults, override)
+	labelTargets, err := autoscalingTargetsFromLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	targets = mergeAutoscalingTargets(targets, labelTargets)
+
+	if targets.MinReplicas == 0 {
+		targets.MinReplicas = serviceReplicas(serviceMap, options)
+		if targets.MinReplicas < 1 {
+			targets.MinReplicas = 1
+		}
+	}
+	if targets.MaxReplicas == 0 {
+		targets.MaxReplicas = targets.MinReplicas * defaultMaxReplicasFactor
+	}
+	if targets.MaxReplicas < targets.MinReplicas {
+		return nil, fmt.Errorf("maxReplicas (%d) is lower than minReplicas (%d)", targets.MaxReplicas, targets.MinReplicas)
+	}
+
+	if targets.TargetCPU == 0 && targets.TargetMemory == 0 {
+		var resources *ResourceRequirements
+		if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok {
+			if resourcesConfig, ok := deploy["resources"].(map[string]interface{}); ok {
+				resources, _ = generateResourceRequirements(resourcesConfig)
+			}
+		}
+		hasCPU := resources != nil && (resources.Limits["cpu"] != "" || resources.Requests["cpu"] != "")
+		hasMemory := resources != nil && (resources.Limits["memory"] != "" || resources.Requests["memory"] != "")
+		if hasCPU || !hasMemory {
+			targets.TargetCPU = defaultTargetUtilization
+		}
+		if hasMemory {
+			targets.TargetMemory = defaultTargetUtilization
+		}
+	}
+
+	minReplicas := targets.MinReplicas
+	hpa := &HorizontalPodAutoscaler{
+		APIVersion: "autoscaling/v2",
+		Kind:       "HorizontalPodAutoscaler",
+		Metadata: Metadata{
+			Name:      fmt.Sprintf("%s-hpa", serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       workloadObjectKind(kind),
+				Name:       serviceName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: targets.MaxReplicas,
+		},
+	}
+
+	for _, metric := range []struct {
+		name   string
+		target int32
+	}{{"cpu", targets.TargetCPU}, {"memory", targets.TargetMemory}} {
+		if metric.target == 0 {
+			continue
+		}
+		utilization := metric.target
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, MetricSpec{
+			Type: "Resource",
+			Resource: &ResourceMetricSource{
+				Name:   metric.name,
+				Target: MetricTarget{Type: "Utilization", AverageUtilization: &utilization},
+			},
+		})
+	}
+
+	return hpa, nil
+}
+
+// GeneratePodDisruptionBudget génère le PDB d'un Deployment ou d'un StatefulSet.
+// Sans configuration, un seul pod peut être indisponible à la fois (maxUnavailable: 1).
+func GeneratePodDisruptionBudget(kind, serviceName string, service interface{}, options GeneratorOptions) (*PodDisruptionBudget, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	labels, _ := serviceMap["labels"].(map[string]string)
+	override, hasOverride := options.DisruptionBudget.Services[serviceName]
+	if !isScalableWorkload(kind) || !labelEnabled(labels, DisruptionBudgetLabelPrefix, options.DisruptionBudget.Enabled || hasOverride) {
+		return nil, nil
+	}
+
+	budget := mergeDisruptionBudget(options.DisruptionBudget.Defaults, override)
+	budget = mergeDisruptionBudget(budget, DisruptionBudget{
+		MinAvailable:   strings.TrimSpace(labels[DisruptionBudgetLabelPrefix+"min-available"]),
+		MaxUnavailable: strings.TrimSpace(labels[DisruptionBudgetLabelPrefix+"max-unavailable"]),
+	})
+
+	if budget.MinAvailable != "" && budget.MaxUnavailable != "" {
+		return nil, fmt.Errorf("minAvailable and maxUnavailable are mutually exclusive")
+	}
+	if budget.MinAvailable == "" && budget.MaxUnavailable == "" {
+		budget.MaxUnavailable = defaultPodMaxUnavailable
+	}
+
+	pdb := &PodDisruptionBudget{
+		APIVersion: "policy/v1",
+		Kind:       "PodDisruptionBudget",
+		Metadata: Metadata{
+			Name:      fmt.Sprintf("%s-pdb", serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: PodDisruptionBudgetSpec{
+			Selector: &LabelSelector{
+				MatchLabels: map[string]string{"app": serviceName},
+			},
+		},
+	}
+
+	for _, value := range []struct {
+		name   string
+		value  string
+		target **IntOrString
+	}{
+		{"minAvailable", budget.MinAvailable, &pdb.Spec.MinAvailable},
+		{"maxUnavailable", budget.MaxUnavailable, &pdb.Spec.MaxUnavailable},
+	} {
+		if value.value == "" {
+			continue
+		}
+		if !disruptionBudgetValue.MatchString(value.value) {
+			return nil, fmt.Errorf("invalid %s value %q (expected an integer or a percentage)", value.name, value.value)
+		}
+		v := IntOrString(value.value)
+		*value.target = &v
+	}
+
+	return pdb, nil
+}
+
+// isScalableWorkload indique si un type de workload accepte un HPA et un PDB
+func isScalableWorkload(kind string) bool {
+	return kind == WorkloadDeployment || kind == WorkloadStatefulSet
+}
+
+// workloadObjectKind retourne le kind Kubernetes d'un type de workload
+func workloadObjectKind(kind string) string {
+	if kind == WorkloadStatefulSet {
+		return "StatefulSet"
+	}
+	return "Deployment"
+}
+
+// labelEnabled applique le label <prefix>enabled d'un service à la valeur par défaut
+func labelEnabled(labels map[string]string, prefix string, enabled bool) bool {
+	if value, ok := labels[prefix+"enabled"]; ok {
+		if parsed, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return parsed
+		}
+	}
+	return enabled
+}
+
+// autoscalingTargetsFromLabels lit les cibles d'autoscaling portées par les labels d'un service
+func autoscalingTargetsFromLabels(labels map[string]string) (AutoscalingTargets, error) {
+	var targets AutoscalingTargets
+
+	for _, label := range []struct {
+		key    string
+		target *int32
+	}{
+		{"min-replicas", &targets.MinReplicas},
+		{"max-replicas", &targets.MaxReplicas},
+		{"cpu", &targets.TargetCPU},
+		{"memory", &targets.TargetMemory},
+	} {
+		value, ok := labels[AutoscalingLabelPrefix+label.key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(value), "%"), 10, 32)
+		if err != nil || n < 1 {
+			return targets, fmt.Errorf("invalid %s%s label value %q", AutoscalingLabelPrefix, label.key, value)
+		}
+		*label.target = int32(n)
+	}
+
+	return targets, nil
+}
+
+// mergeAutoscalingTargets surcharge les cibles définies dans override
+func mergeAutoscalingTargets(base, override AutoscalingTargets) AutoscalingTargets {
+	if override.MinReplicas > 0 {
+		base.MinReplicas = override.MinReplicas
+	}
+	if override.MaxReplicas > 0 {
+		base.MaxReplicas = override.MaxReplicas
+	}
+	// Les cibles d'utilisation sont remplacées ensemble pour ne pas mélanger deux configurations
+	if override.TargetCPU > 0 || override.TargetMemory > 0 {
+		base.TargetCPU = override.TargetCPU
+		base.TargetMemory = override.TargetMemory
+	}
+	return base
+}
+
+// mergeDisruptionBudget surcharge le budget lorsque override en définit un
+func mergeDisruptionBudget(base, override DisruptionBudget) DisruptionBudget {
+	if override.MinAvailable != "" || override.MaxUnavailable != "" {
+		return override
+	}
+	return base
+}
@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateHorizontalPodAutoscaler(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "api",
+		"deploy": map[string]interface{}{
+			"replicas": 2,
+			"resources": map[string]interface{}{
+				"limits": map[string]interface{}{"memory": "512M"},
+			},
+		},
+	}
+	options := DefaultGeneratorOptions()
+
+	// Désactivé par défaut
+	hpa, err := GenerateHorizontalPodAutoscaler(WorkloadDeployment, "api", service, options)
+	require.NoError(t, err)
+	assert.Nil(t, hpa)
+
+	options.Autoscaling.Enabled = true
+	hpa, err = GenerateHorizontalPodAutoscaler(WorkloadDeployment, "api", service, options)
+	require.NoError(t, err)
+	require.NotNil(t, hpa)
+	assert.Equal(t, "autoscaling/v2", hpa.APIVersion)
+	assert.Equal(t, "api-hpa", hpa.GetName())
+	assert.Equal(t, CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "api"}, hpa.Spec.ScaleTargetRef)
+	assert.Equal(t, int32Ptr(2), hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(6), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, "memory", hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32Ptr(80), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+
+	// Les labels priment sur les options du service, qui priment sur les options globales
+	options.Autoscaling.Defaults = AutoscalingTargets{MaxReplicas: 10, TargetCPU: 70}
+	options.Autoscaling.Services = map[string]AutoscalingTargets{"api": {MinReplicas: 3}}
+	service["labels"] = map[string]string{AutoscalingLabelPrefix + "cpu": "60%"}
+	hpa, err = GenerateHorizontalPodAutoscaler(WorkloadStatefulSet, "api", service, options)
+	require.NoError(t, err)
+	assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, int32Ptr(3), hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, "cpu", hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32Ptr(60), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+
+	service["labels"] = map[string]string{AutoscalingLabelPrefix + "max-replicas": "1"}
+	_, err = GenerateHorizontalPodAutoscaler(WorkloadDeployment, "api", service, options)
+	assert.Error(t, err)
+
+	// Pas de HPA pour les DaemonSets, Jobs et CronJobs
+	hpa, err = GenerateHorizontalPodAutoscaler(WorkloadDaemonSet, "api", service, options)
+	require.NoError(t, err)
+	assert.Nil(t, hpa)
+}
+
+func TestGeneratePodDisruptionBudget(t *testing.T) {
+	service := map[string]interface{}{"image": "api"}
+	options := DefaultGeneratorOptions()
+	options.DisruptionBudget.Enabled = true
+
+	pdb, err := GeneratePodDisruptionBudget(WorkloadDeployment, "api", service, options)
+	require.NoError(t, err)
+	require.NotNil(t, pdb)
+	assert.Equal(t, "policy/v1", pdb.APIVersion)
+	assert.Equal(t, "api-pdb", pdb.GetName())
+	assert.Nil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, IntOrString("1"), *pdb.Spec.MaxUnavailable)
+	assert.Equal(t, map[string]string{"app": "api"}, pdb.Spec.Selector.MatchLabels)
+
+	options.DisruptionBudget.Defaults = DisruptionBudget{MaxUnavailable: "25%"}
+	service["labels"] = map[string]string{DisruptionBudgetLabelPrefix + "min-available": "50%"}
+	pdb, err = GeneratePodDisruptionBudget(WorkloadDeployment, "api", service, options)
+	require.NoError(t, err)
+	assert.Equal(t, IntOrString("50%"), *pdb.Spec.MinAvailable)
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+
+	service["labels"] = map[string]string{DisruptionBudgetLabelPrefix + "enabled": "false"}
+	pdb, err = GeneratePodDisruptionBudget(WorkloadDeployment, "api", service, options)
+	require.NoError(t, err)
+	assert.Nil(t, pdb)
+
+	options.DisruptionBudget.Services = map[string]DisruptionBudget{"api": {MinAvailable: "half"}}
+	delete(service, "labels")
+	_, err = GeneratePodDisruptionBudget(WorkloadDeployment, "api", service, options)
+	assert.Error(t, err)
+}
+
+func TestServiceReplicas(t *testing.T) {
+	deployment, err := GenerateDeployment("api", map[string]interface{}{
+		"image":  "api",
+		"deploy": map[string]interface{}{"replicas": 4},
+	}, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), deployment.Spec.Replicas)
+}
@@ -0,0 +1,247 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Conditions docker-compose d'une dépendance (depends_on.<service>.condition)
+const (
+	DependencyServiceStarted        = "service_started"
+	DependencyServiceHealthy        = "service_healthy"
+	DependencyCompletedSuccessfully = "service_completed_successfully"
+)
+
+// DependencyWaiterName nom du ServiceAccount, du Role et du RoleBinding autorisant
+// les init containers à attendre la complétion des Jobs
+const DependencyWaiterName = "dependency-waiter"
+
+// Valeurs par défaut des init containers d'attente
+const (
+	DefaultDependencyWaitImage    = "busybox:1.36"
+	DefaultDependencyJobWaitImage = "bitnami/kubectl:1.30"
+	DefaultDependencyWaitTimeout  = 300
+)
+
+// DependencyWaitOptions options des init containers générés pour depends_on
+type DependencyWaitOptions struct {
+	// Image image attendant le port d'un Service (sh et nc requis)
+	Image string `json:"image"`
+	// JobImage image attendant la complétion d'un Job (sh et kubectl requis)
+	JobImage string `json:"jobImage"`
+	// TimeoutSeconds délai d'attente de chaque dépendance, 0 pour attendre indéfiniment
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+}
+
+// DependencyWait attente d'une dépendance avant le démarrage du conteneur principal
+type DependencyWait struct {
+	Service   string
+	Condition string
+	// Port port du Service attendu ; 0 lorsque la complétion du Job est attendue
+	Port int32
+}
+
+// UnresolvedDependency dépendance qui ne peut pas être attendue par un init container
+type UnresolvedDependency struct {
+	Service string
+	Reason  string
+}
+
+// ResolveDependencyWaits détermine comment attendre chaque dépendance d'un service, triées par nom.
+// Chaque entrée de depends_on porte la condition ainsi que le type de workload (kind) et les ports
+// du service ciblé, absents lorsque celui-ci n'est pas converti.
+//
+// service_started et service_healthy attendent que le port du Service réponde : un Service ne
+// route que vers des pods prêts, la readiness probe issue du healthcheck fait donc foi.
+// service_completed_successfully attend la complétion du Job de la dépendance.
+func ResolveDependencyWaits(serviceMap map[string]interface{}) ([]DependencyWait, []UnresolvedDependency) {
+	dependencies, _ := serviceMap["depends_on"].(map[string]interface{})
+
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var waits []DependencyWait
+	var unresolved []UnresolvedDependency
+	for _, name := range names {
+		dependency, _ := dependencies[name].(map[string]interface{})
+		condition := stringValue(dependency["condition"])
+		if condition == "" {
+			condition = DependencyServiceStarted
+		}
+
+		kind := stringValue(dependency["kind"])
+		if kind == "" {
+			unresolved = append(unresolved, UnresolvedDependency{Service: name, Reason: fmt.Sprintf("service %s is not converted", name)})
+			continue
+		}
+
+		switch condition {
+		case DependencyCompletedSuccessfully:
+			if kind != WorkloadJob {
+				unresolved = append(unresolved, UnresolvedDependency{
+					Service: name,
+					Reason:  fmt.Sprintf("%s requires service %s to be converted to a Job, not a %s", condition, name, kind),
+				})
+				continue
+			}
+			waits = append(waits, DependencyWait{Service: name, Condition: condition})
+		case DependencyServiceStarted, DependencyServiceHealthy:
+			port := dependencyPort(dependency["ports"])
+			if port == 0 {
+				unresolved = append(unresolved, UnresolvedDependency{
+					Service: name,
+					Reason:  fmt.Sprintf("service %s exposes no TCP port to wait for", name),
+				})
+				continue
+			}
+			waits = append(waits, DependencyWait{Service: name, Condition: condition, Port: port})
+		default:
+			unresolved = append(unresolved, UnresolvedDependency{
+				Service: name,
+				Reason:  fmt.Sprintf("unsupported condition %q", condition),
+			})
+		}
+	}
+
+	return waits, unresolved
+}
+
+// dependencyPort retourne le premier port TCP du Service d'une dépendance
+func dependencyPort(ports interface{}) int32 {
+	entries, ok := ports.([]interface{})
+	if !ok || len(entries) == 0 {
+		return 0
+	}
+	specs, err := parsePortEntries(entries)
+	if err != nil {
+		return 0
+	}
+	for _, spec := range specs {
+		if spec.Protocol == "TCP" {
+			return spec.servicePort()
+		}
+	}
+	return 0
+}
+
+// generateDependencyInitContainers génère un init container par dépendance attendue
+func generateDependencyInitContainers(serviceMap map[string]interface{}, options GeneratorOptions) []Container {
+	waits, _ := ResolveDependencyWaits(serviceMap)
+
+	containers := make([]Container, 0, len(waits))
+	for _, wait := range waits {
+		container := Container{
+			Name:            toDNSLabel("wait-for-" + wait.Service),
+			ImagePullPolicy: options.ImagePullPolicy,
+		}
+
+		if wait.Port == 0 {
+			container.Image = options.DependencyWait.JobImage
+			container.Command = []string{"sh", "-c", waitForJobScript(wait.Service, options.DependencyWait.TimeoutSeconds)}
+		} else {
+			container.Image = options.DependencyWait.Image
+			container.Command = []string{"sh", "-c", waitForPortScript(wait.Service, wait.Port, options.DependencyWait.TimeoutSeconds)}
+		}
+
+		containers = append(containers, container)
+	}
+
+	return containers
+}
+
+// waitForPortScript retourne le script shell attendant qu'un port TCP accepte les connexions
+func waitForPortScript(host string, port int32, timeoutSeconds int32) string {
+	target := host + " " + strconv.Itoa(int(port))
+	if timeoutSeconds <= 0 {
+		return fmt.Sprintf("until nc -z %s; do echo waiting for %s; sleep 2; done", target, host)
+	}
+	return fmt.Sprintf(
+		"deadline=$(($(date +%%s) + %d)); until nc -z %s; do if [ $(date +%%s) -ge $deadline ]; then echo timed out waiting for %s; exit 1; fi; echo waiting for %s; sleep 2; done",
+		timeoutSeconds, target, host, host,
+	)
+}
+
+// waitForJobScript retourne le script shell attendant la complétion d'un Job. Le Job peut ne pas
+// encore exister : il est interrogé jusqu'à ce qu'il porte la condition Complete ou Failed, cette
+// dernière faisant échouer l'init container au lieu d'attendre jusqu'au délai.
+func waitForJobScript(job string, timeoutSeconds int32) string {
+	status := fmt.Sprintf(`status=$(kubectl get job/%s -o jsonpath='{.status.conditions[?(@.status=="True")].type}' 2>/dev/null)`, job)
+	check := fmt.Sprintf(`case "$status" in *Failed*) echo job %s failed; exit 1;; *Complete*) exit 0;; esac`, job)
+	if timeoutSeconds <= 0 {
+		return fmt.Sprintf("while true; do %s; %s; echo waiting for job %s; sleep 2; done", status, check, job)
+	}
+	return fmt.Sprintf(
+		"deadline=$(($(date +%%s) + %d)); while true; do %s; %s; if [ $(date +%%s) -ge $deadline ]; then echo timed out waiting for job %s; exit 1; fi; echo waiting for job %s; sleep 2; done",
+		timeoutSeconds, status, check, job, job,
+	)
+}
+
+// waitsForJobs indique si un service attend la complétion d'au moins un Job
+func waitsForJobs(serviceMap map[string]interface{}) bool {
+	waits, _ := ResolveDependencyWaits(serviceMap)
+	for _, wait := range waits {
+		if wait.Port == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateDependencyWaiterRBAC génère le ServiceAccount et les droits de lecture des Jobs utilisés
+// par les init containers attendant un Job (service_completed_successfully).
+// Aucun objet n'est généré si aucun service n'attend de Job.
+func GenerateDependencyWaiterRBAC(services map[string]interface{}, options GeneratorOptions) []KubernetesObject {
+	needed := false
+	for _, service := range services {
+		if serviceMap, ok := service.(map[string]interface{}); ok && waitsForJobs(serviceMap) {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil
+	}
+
+	metadata := Metadata{
+		Name:      DependencyWaiterName,
+		Namespace: options.Namespace,
+		Labels:    mergeLabels(options.Labels, nil),
+	}
+
+	return []KubernetesObject{
+		&ServiceAccount{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+			Metadata:   metadata,
+		},
+		&Role{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "Role",
+			Metadata:   metadata,
+			Rules: []PolicyRule{{
+				APIGroups: []string{"batch"},
+				Resources: []string{"jobs"},
+				Verbs:     []string{"get", "list", "watch"},
+			}},
+		},
+		&RoleBinding{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "RoleBinding",
+			Metadata:   metadata,
+			Subjects: []Subject{{
+				Kind:      "ServiceAccount",
+				Name:      DependencyWaiterName,
+				Namespace: options.Namespace,
+			}},
+			RoleRef: RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "Role",
+				Name:     DependencyWaiterName,
+			},
+		},
+	}
+}
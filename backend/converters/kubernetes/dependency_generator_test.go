@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dependentService() map[string]interface{} {
+	return map[string]interface{}{
+		"image": "api",
+		"depends_on": map[string]interface{}{
+			"db": map[string]interface{}{
+				"condition": DependencyServiceHealthy,
+				"kind":      WorkloadStatefulSet,
+				"ports":     []interface{}{"5432"},
+			},
+			"migrate": map[string]interface{}{
+				"condition": DependencyCompletedSuccessfully,
+				"kind":      WorkloadJob,
+			},
+			"cache": map[string]interface{}{
+				"condition": DependencyServiceStarted,
+				"kind":      WorkloadDeployment,
+			},
+			"missing": map[string]interface{}{"condition": DependencyServiceStarted},
+		},
+	}
+}
+
+func TestResolveDependencyWaits(t *testing.T) {
+	waits, unresolved := ResolveDependencyWaits(dependentService())
+
+	assert.Equal(t, []DependencyWait{
+		{Service: "db", Condition: DependencyServiceHealthy, Port: 5432},
+		{Service: "migrate", Condition: DependencyCompletedSuccessfully},
+	}, waits)
+
+	require.Len(t, unresolved, 2)
+	assert.Equal(t, "cache", unresolved[0].Service)
+	assert.Contains(t, unresolved[0].Reason, "no TCP port")
+	assert.Equal(t, "missing", unresolved[1].Service)
+	assert.Contains(t, unresolved[1].Reason, "not converted")
+
+	// service_completed_successfully n'est attendu que sur un Job
+	_, unresolved = ResolveDependencyWaits(map[string]interface{}{
+		"depends_on": map[string]interface{}{
+			"web": map[string]interface{}{"condition": DependencyCompletedSuccessfully, "kind": WorkloadDeployment},
+		},
+	})
+	require.Len(t, unresolved, 1)
+	assert.Contains(t, unresolved[0].Reason, "Job")
+}
+
+func TestDependencyInitContainers(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.DependencyWait = DependencyWaitOptions{Image: "alpine:3.20", JobImage: "kubectl:latest", TimeoutSeconds: 60}
+
+	deployment, err := GenerateDeployment("api", dependentService(), options)
+	require.NoError(t, err)
+
+	spec := deployment.Spec.Template.Spec
+	require.Len(t, spec.InitContainers, 2)
+
+	db := spec.InitContainers[0]
+	assert.Equal(t, "wait-for-db", db.Name)
+	assert.Equal(t, "alpine:3.20", db.Image)
+	assert.Equal(t, []string{"sh", "-c"}, db.Command[:2])
+	assert.Contains(t, db.Command[2], "nc -z db 5432")
+	assert.Contains(t, db.Command[2], "+ 60")
+
+	migrate := spec.InitContainers[1]
+	assert.Equal(t, "wait-for-migrate", migrate.Name)
+	assert.Equal(t, "kubectl:latest", migrate.Image)
+	assert.Equal(t, []string{"sh", "-c"}, migrate.Command[:2])
+	assert.Contains(t, migrate.Command[2], "kubectl get job/migrate")
+	assert.Contains(t, migrate.Command[2], "+ 60")
+	assert.Equal(t, DependencyWaiterName, spec.ServiceAccountName)
+
+	// Sans délai, l'attente est illimitée
+	options.DependencyWait.TimeoutSeconds = 0
+	deployment, err = GenerateDeployment("api", dependentService(), options)
+	require.NoError(t, err)
+	assert.NotContains(t, deployment.Spec.Template.Spec.InitContainers[0].Command[2], "deadline")
+	assert.NotContains(t, deployment.Spec.Template.Spec.InitContainers[1].Command[2], "deadline")
+
+	// Sans dépendance, aucun init container
+	deployment, err = GenerateDeployment("db", map[string]interface{}{"image": "postgres"}, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Spec.Template.Spec.InitContainers)
+	assert.Empty(t, deployment.Spec.Template.Spec.ServiceAccountName)
+}
+
+// TestWaitForJobScript exécute le script d'attente avec un kubectl simulé qui répond NotFound
+// aux premiers appels puis retourne les conditions du Job
+func TestWaitForJobScript(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	dir := t.TempDir()
+	kubectl := `#!/bin/sh
+count=$(($(cat "$STATE" 2>/dev/null || echo 0) + 1))
+echo $count > "$STATE"
+if [ $count -le "$NOT_FOUND_CALLS" ]; then echo 'Error from server (NotFound): jobs.batch "migrate" not found' >&2; exit 1; fi
+printf '%s' "$JOB_STATUS"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(kubectl), 0o755))
+
+	run := func(notFoundCalls, status string) (string, error) {
+		cmd := exec.Command("sh", "-c", waitForJobScript("migrate", 30))
+		cmd.Env = append(os.Environ(),
+			"PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"),
+			"STATE="+filepath.Join(dir, "state-"+status),
+			"NOT_FOUND_CALLS="+notFoundCalls,
+			"JOB_STATUS="+status,
+		)
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+
+	// Le Job absent est attendu jusqu'à sa création, puis sa complétion termine l'attente
+	output, err := run("1", "Complete")
+	require.NoError(t, err, output)
+	assert.Contains(t, output, "waiting for job migrate")
+
+	// Un Job en échec fait échouer l'init container sans attendre le délai
+	output, err = run("0", "FailureTarget Failed")
+	require.Error(t, err)
+	assert.Contains(t, output, "job migrate failed")
+}
+
+func TestGenerateDependencyWaiterRBAC(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Namespace = "apps"
+
+	assert.Empty(t, GenerateDependencyWaiterRBAC(map[string]interface{}{"db": map[string]interface{}{"image": "postgres"}}, options))
+
+	objects := GenerateDependencyWaiterRBAC(map[string]interface{}{"api": dependentService()}, options)
+	require.Len(t, objects, 3)
+	assert.Equal(t, []string{"ServiceAccount", "Role", "RoleBinding"}, []string{objects[0].GetKind(), objects[1].GetKind(), objects[2].GetKind()})
+
+	role := objects[1].(*Role)
+	assert.Equal(t, []string{"jobs"}, role.Rules[0].Resources)
+	binding := objects[2].(*RoleBinding)
+	assert.Equal(t, Subject{Kind: "ServiceAccount", Name: DependencyWaiterName, Namespace: "apps"}, binding.Subjects[0])
+	assert.Equal(t, DependencyWaiterName, binding.RoleRef.Name)
+}
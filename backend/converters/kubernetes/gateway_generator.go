@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateHTTPRoute génère la HTTPRoute Gateway API d'un service exposé en HTTP,
+// ou nil si le service n'est pas exposé ou si le mode d'exposition est ingress
+func GenerateHTTPRoute(serviceName string, service interface{}, options GeneratorOptions) (*HTTPRoute, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	if options.Ingress.Mode != ExposureModeGatewayAPI {
+		return nil, nil
+	}
+
+	exposure, err := resolveHTTPExposure(serviceName, serviceMap, options)
+	if err != nil || exposure == nil {
+		return nil, err
+	}
+
+	// La terminaison TLS est portée par les listeners de la Gateway
+	matchType := "PathPrefix"
+	if exposure.PathType == "Exact" {
+		matchType = "Exact"
+	}
+
+	route := &HTTPRoute{
+		APIVersion: "gateway.networking.k8s.io/v1",
+		Kind:       "HTTPRoute",
+		Metadata: Metadata{
+			Name:      fmt.Sprintf("%s-route", serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: HTTPRouteSpec{
+			ParentRefs: []ParentReference{{
+				Name:      gatewayName(options),
+				Namespace: options.Ingress.GatewayNamespace,
+			}},
+			Hostnames: exposure.Hosts,
+			Rules: []HTTPRouteRule{{
+				Matches:     []HTTPRouteMatch{{Path: &HTTPPathMatch{Type: matchType, Value: exposure.Path}}},
+				BackendRefs: []HTTPBackendRef{{Name: serviceName, Port: exposure.Port}},
+			}},
+		},
+	}
+
+	return route, nil
+}
+
+// GenerateGateway génère la Gateway partagée par les HTTPRoute lorsque l'option gatewayClassName est renseignée.
+// Un listener HTTP est toujours créé ; un listener HTTPS est ajouté quand TLS est configuré
+// (secret explicite ou certificat émis par cert-manager).
+func GenerateGateway(options GeneratorOptions) (*Gateway, error) {
+	switch options.Ingress.Mode {
+	case "", ExposureModeIngress, ExposureModeGatewayAPI:
+	default:
+		return nil, fmt.Errorf("unknown exposure mode %q (expected %s or %s)", options.Ingress.Mode, ExposureModeIngress, ExposureModeGatewayAPI)
+	}
+
+	if !options.Ingress.Enabled || options.Ingress.Mode != ExposureModeGatewayAPI || options.Ingress.GatewayClassName == "" {
+		return nil, nil
+	}
+
+	name := gatewayName(options)
+	namespace := options.Ingress.GatewayNamespace
+	if namespace == "" {
+		namespace = options.Namespace
+	}
+
+	// Les routes sont créées dans le namespace de la conversion : une Gateway placée dans un autre
+	// namespace n'accepte que les routes de celui-ci
+	routeNamespaces := &RouteNamespaces{From: "Same"}
+	if namespace != options.Namespace {
+		routeNamespaces = &RouteNamespaces{
+			From:     "Selector",
+			Selector: &LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": options.Namespace}},
+		}
+	}
+	allowedRoutes := &AllowedRoutes{Namespaces: routeNamespaces}
+
+	hostname := ""
+	if options.Ingress.Domain != "" {
+		hostname = "*." + strings.TrimPrefix(options.Ingress.Domain, ".")
+	}
+
+	gateway := &Gateway{
+		APIVersion: "gateway.networking.k8s.io/v1",
+		Kind:       "Gateway",
+		Metadata: Metadata{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    mergeLabels(options.Labels, nil),
+		},
+		Spec: GatewaySpec{
+			GatewayClassName: options.Ingress.GatewayClassName,
+			Listeners: []Listener{{
+				Name:          "http",
+				Hostname:      hostname,
+				Port:          80,
+				Protocol:      "HTTP",
+				AllowedRoutes: allowedRoutes,
+			}},
+		},
+	}
+
+	certManager := options.Ingress.CertManagerIssuer != "" || options.Ingress.CertManagerClusterIssuer != ""
+	if options.Ingress.TLSSecret != "" || certManager {
+		secretName := options.Ingress.TLSSecret
+		if secretName == "" {
+			secretName = toDNSLabel(name + "-tls")
+		}
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, Listener{
+			Name:     "https",
+			Hostname: hostname,
+			Port:     443,
+			Protocol: "HTTPS",
+			TLS: &GatewayTLSConfig{
+				Mode:            "Terminate",
+				CertificateRefs: []SecretObjectReference{{Name: secretName}},
+			},
+			AllowedRoutes: allowedRoutes,
+		})
+
+		if options.Ingress.CertManagerClusterIssuer != "" {
+			gateway.Metadata.Annotations = map[string]string{CertManagerClusterIssuerAnnotation: options.Ingress.CertManagerClusterIssuer}
+		} else if options.Ingress.CertManagerIssuer != "" {
+			gateway.Metadata.Annotations = map[string]string{CertManagerIssuerAnnotation: options.Ingress.CertManagerIssuer}
+		}
+	}
+
+	return gateway, nil
+}
+
+// gatewayName retourne le nom de la Gateway ciblée par les HTTPRoute
+func gatewayName(options GeneratorOptions) string {
+	if options.Ingress.GatewayName == "" {
+		return "gateway"
+	}
+	return toDNSLabel(options.Ingress.GatewayName)
+}
@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateHTTPRoute(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "web",
+		"ports": []interface{}{"8081:80"},
+	}
+	options := ingressOptions(IngressOptions{
+		Mode:             ExposureModeGatewayAPI,
+		Domain:           "example.com",
+		PathPrefix:       "/app",
+		GatewayName:      "edge",
+		GatewayNamespace: "infra",
+	})
+
+	route, err := GenerateHTTPRoute("web", service, options)
+	require.NoError(t, err)
+	require.NotNil(t, route)
+	assert.Equal(t, "gateway.networking.k8s.io/v1", route.APIVersion)
+	assert.Equal(t, "web-route", route.GetName())
+	assert.Equal(t, []ParentReference{{Name: "edge", Namespace: "infra"}}, route.Spec.ParentRefs)
+	assert.Equal(t, []string{"web.example.com"}, route.Spec.Hostnames)
+	require.Len(t, route.Spec.Rules, 1)
+	assert.Equal(t, &HTTPPathMatch{Type: "PathPrefix", Value: "/app"}, route.Spec.Rules[0].Matches[0].Path)
+	assert.Equal(t, []HTTPBackendRef{{Name: "web", Port: 8081}}, route.Spec.Rules[0].BackendRefs)
+
+	// En mode Gateway API, aucun Ingress n'est généré
+	ingress, err := GenerateIngress("web", service, options)
+	require.NoError(t, err)
+	assert.Nil(t, ingress)
+
+	// Et inversement en mode ingress
+	options.Ingress.Mode = ExposureModeIngress
+	route, err = GenerateHTTPRoute("web", service, options)
+	require.NoError(t, err)
+	assert.Nil(t, route)
+}
+
+func TestGenerateGateway(t *testing.T) {
+	options := ingressOptions(IngressOptions{
+		Mode:              ExposureModeGatewayAPI,
+		Domain:            "example.com",
+		CertManagerIssuer: "letsencrypt",
+	})
+
+	// Sans classe, la Gateway existante est seulement référencée
+	gateway, err := GenerateGateway(options)
+	require.NoError(t, err)
+	assert.Nil(t, gateway)
+
+	options.Ingress.GatewayClassName = "istio"
+	gateway, err = GenerateGateway(options)
+	require.NoError(t, err)
+	require.NotNil(t, gateway)
+	assert.Equal(t, "gateway", gateway.GetName())
+	assert.Equal(t, "default", gateway.Metadata.Namespace)
+	assert.Equal(t, "istio", gateway.Spec.GatewayClassName)
+	assert.Equal(t, map[string]string{CertManagerIssuerAnnotation: "letsencrypt"}, gateway.Metadata.Annotations)
+
+	require.Len(t, gateway.Spec.Listeners, 2)
+	assert.Equal(t, "*.example.com", gateway.Spec.Listeners[0].Hostname)
+	assert.Equal(t, "Same", gateway.Spec.Listeners[0].AllowedRoutes.Namespaces.From)
+	https := gateway.Spec.Listeners[1]
+	assert.Equal(t, int32(443), https.Port)
+	assert.Equal(t, []SecretObjectReference{{Name: "gateway-tls"}}, https.TLS.CertificateRefs)
+
+	// Une Gateway dans un autre namespace n'accepte que les routes du namespace de la conversion
+	options.Ingress.GatewayNamespace = "gateways"
+	gateway, err = GenerateGateway(options)
+	require.NoError(t, err)
+	require.NotNil(t, gateway)
+	assert.Equal(t, "gateways", gateway.Metadata.Namespace)
+	for _, listener := range gateway.Spec.Listeners {
+		assert.Equal(t, &RouteNamespaces{
+			From:     "Selector",
+			Selector: &LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "default"}},
+		}, listener.AllowedRoutes.Namespaces)
+	}
+
+	options.Ingress.Mode = "mesh"
+	_, err = GenerateGateway(options)
+	assert.Error(t, err)
+}
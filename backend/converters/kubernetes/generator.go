@@ -1,9 +1,13 @@
 package kubernetes
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Generator interface pour générer des manifests Kubernetes
@@ -46,6 +50,12 @@ func GenerateAllInOneManifest(projectName string, objects []KubernetesObject) (*
 	}, nil
 }
 
+// Annotations du template de pod portant l'empreinte de la configuration du service
+const (
+	ConfigChecksumAnnotation = "checksum/config"
+	SecretChecksumAnnotation = "checksum/secret"
+)
+
 // GeneratorOptions options de génération
 type GeneratorOptions struct {
 	Namespace       string            `json:"namespace"`
@@ -54,6 +64,26 @@ type GeneratorOptions struct {
 	ImagePullPolicy string            `json:"imagePullPolicy"`
 	ServiceType     string            `json:"serviceType"`
 	Replicas        int32             `json:"replicas"`
+
+	// StatefulServices liste les services à générer en StatefulSet
+	StatefulServices []string `json:"statefulServices"`
+	// NamedVolumesAsStatefulSet génère un StatefulSet pour les services montant des volumes nommés
+	NamedVolumesAsStatefulSet bool `json:"namedVolumesAsStatefulSet"`
+	// SecretPatterns motifs des variables d'environnement placées dans le Secret du service
+	SecretPatterns []string `json:"secretPatterns"`
+	// Ingress options d'exposition HTTP des services
+	Ingress IngressOptions `json:"ingress"`
+	// Autoscaling et DisruptionBudget génèrent un HPA et un PDB par Deployment ou StatefulSet
+	Autoscaling      AutoscalingOptions      `json:"autoscaling"`
+	DisruptionBudget DisruptionBudgetOptions `json:"disruptionBudget"`
+	// NetworkPolicies isole les services selon leurs réseaux docker-compose
+	NetworkPolicies bool `json:"networkPolicies"`
+	// PublishedPortsSource pods autorisés à joindre les ports publiés lorsque les NetworkPolicies sont générées
+	PublishedPortsSource PublishedPortsSource `json:"publishedPortsSource"`
+	// DependencyWait configure les init containers attendant les dépendances (depends_on)
+	DependencyWait DependencyWaitOptions `json:"dependencyWait"`
+	// Hardening durcit chaque pod selon un niveau des Pod Security Standards
+	Hardening HardeningOptions `json:"hardening"`
 }
 
 // DefaultGeneratorOptions retourne les options par défaut
@@ -65,6 +95,18 @@ func DefaultGeneratorOptions() GeneratorOptions {
 		ImagePullPolicy: "IfNotPresent",
 		ServiceType:     "ClusterIP",
 		Replicas:        1,
+
+		NamedVolumesAsStatefulSet: true,
+		SecretPatterns:            DefaultSecretPatterns,
+		DependencyWait: DependencyWaitOptions{
+			Image:          DefaultDependencyWaitImage,
+			JobImage:       DefaultDependencyJobWaitImage,
+			TimeoutSeconds: DefaultDependencyWaitTimeout,
+		},
+		Hardening: HardeningOptions{
+			Level:        HardeningNone,
+			ScratchPaths: DefaultHardeningScratchPaths,
+		},
 	}
 }
 
@@ -75,6 +117,11 @@ func GenerateDeployment(serviceName string, service interface{}, options Generat
 		return nil, fmt.Errorf("invalid service format for %s", serviceName)
 	}
 
+	template, err := generatePodTemplate(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
 	deployment := &Deployment{
 		APIVersion: "apps/v1",
 		Kind:       "Deployment",
@@ -84,28 +131,70 @@ func GenerateDeployment(serviceName string, service interface{}, options Generat
 			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
 		},
 		Spec: DeploymentSpec{
-			Replicas: options.Replicas,
+			Replicas: serviceReplicas(serviceMap, options),
 			Selector: &LabelSelector{
 				MatchLabels: map[string]string{"app": serviceName},
 			},
-			Template: PodTemplateSpec{
-				Metadata: Metadata{
-					Labels: map[string]string{"app": serviceName},
-				},
-				Spec: PodSpec{
-					RestartPolicy: "Always",
-				},
-			},
+			Template: *template,
 		},
 	}
 
+	return deployment, nil
+}
+
+// serviceReplicas retourne le nombre de réplicas d'un service : deploy.replicas, sinon l'option replicas
+func serviceReplicas(serviceMap map[string]interface{}, options GeneratorOptions) int32 {
+	if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok {
+		if _, ok := deploy["replicas"]; ok {
+			return int32Value(deploy["replicas"])
+		}
+	}
+	return options.Replicas
+}
+
+// generatePodTemplate génère le template de Pod commun à tous les types de workload
+func generatePodTemplate(serviceName string, serviceMap map[string]interface{}, options GeneratorOptions) (*PodTemplateSpec, error) {
+	template := &PodTemplateSpec{
+		Metadata: Metadata{
+			Labels: map[string]string{"app": serviceName},
+		},
+		Spec: PodSpec{
+			RestartPolicy: "Always",
+		},
+	}
+
+	// Les réseaux docker-compose deviennent des labels sélectionnés par les NetworkPolicies
+	if options.NetworkPolicies {
+		template.Metadata.Labels = mergeLabels(template.Metadata.Labels, networkLabels(serviceMap))
+	}
+
 	// Générer le conteneur principal
 	container, err := generateContainer(serviceName, serviceMap, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate container for %s: %w", serviceName, err)
 	}
 
-	deployment.Spec.Template.Spec.Containers = []Container{*container}
+	template.Spec.Containers = []Container{*container}
+	template.Spec.SecurityContext = generatePodSecurityContext(serviceMap)
+
+	// Attendre les dépendances (depends_on) avant de démarrer le conteneur principal
+	if initContainers := generateDependencyInitContainers(serviceMap, options); len(initContainers) > 0 {
+		template.Spec.InitContainers = initContainers
+		if waitsForJobs(serviceMap) {
+			template.Spec.ServiceAccountName = DependencyWaiterName
+		}
+	}
+
+	// Empreinte de la configuration pour redémarrer les pods quand elle change
+	if env, ok := serviceMap["environment"]; ok {
+		checksums, err := environmentChecksums(env, options.SecretPatterns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute configuration checksum for %s: %w", serviceName, err)
+		}
+		if len(checksums) > 0 {
+			template.Metadata.Annotations = checksums
+		}
+	}
 
 	// Générer les volumes si nécessaire
 	volumes, volumeMounts, err := generateVolumes(serviceMap)
@@ -113,12 +202,20 @@ func GenerateDeployment(serviceName string, service interface{}, options Generat
 		return nil, fmt.Errorf("failed to generate volumes for %s: %w", serviceName, err)
 	}
 
+	// Monter les secrets et configs référencés par le service
+	fileVolumes, fileMounts := generateFileMounts(serviceMap)
+	volumes = append(volumes, fileVolumes...)
+	volumeMounts = append(volumeMounts, fileMounts...)
+
 	if len(volumes) > 0 {
-		deployment.Spec.Template.Spec.Volumes = volumes
-		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+		template.Spec.Volumes = volumes
+		template.Spec.Containers[0].VolumeMounts = volumeMounts
 	}
 
-	return deployment, nil
+	// Durcir le pod selon le niveau des Pod Security Standards demandé
+	hardenPodSpec(&template.Spec, options.Hardening)
+
+	return template, nil
 }
 
 // generateContainer génère un conteneur Kubernetes
@@ -135,14 +232,9 @@ func generateContainer(serviceName string, service map[string]interface{}, optio
 		return nil, fmt.Errorf("no image specified for service %s", serviceName)
 	}
 
-	// Commande et arguments
-	if command, ok := service["command"]; ok {
-		container.Command = normalizeStringSlice(command)
-	}
-
-	if entrypoint, ok := service["entrypoint"]; ok {
-		container.Args = normalizeStringSlice(entrypoint)
-	}
+	// Commande et arguments : l'entrypoint docker remplace l'ENTRYPOINT de l'image comme command
+	// en Kubernetes, la command docker remplace son CMD comme args
+	container.Command, container.Args = containerCommand(service)
 
 	// Working directory
 	if workingDir, ok := service["working_dir"].(string); ok {
@@ -158,13 +250,13 @@ func generateContainer(serviceName string, service map[string]interface{}, optio
 		container.Ports = containerPorts
 	}
 
-	// Variables d'environnement
+	// Variables d'environnement : lues depuis la ConfigMap et le Secret du service
 	if env, ok := service["environment"]; ok {
-		envVars, err := generateEnvVars(env)
+		envFrom, err := generateEnvFrom(serviceName, env, options.SecretPatterns)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate environment variables: %w", err)
 		}
-		container.Env = envVars
+		container.EnvFrom = envFrom
 	}
 
 	// Health check
@@ -179,6 +271,9 @@ func generateContainer(serviceName string, service map[string]interface{}, optio
 		if probes.readiness != nil {
 			container.ReadinessProbe = probes.readiness
 		}
+		if probes.startup != nil {
+			container.StartupProbe = probes.startup
+		}
 	}
 
 	// Ressources
@@ -204,249 +299,361 @@ func generateContainer(serviceName string, service map[string]interface{}, optio
 	return container, nil
 }
 
-// generateContainerPorts génère les ports de conteneur
-func generateContainerPorts(ports interface{}) ([]ContainerPort, error) {
-	var containerPorts []ContainerPort
+// portSpec représente un port normalisé issu de la définition Docker Compose
+type portSpec struct {
+	Name        string
+	Target      int32
+	Published   int32
+	Protocol    string
+	HostIP      string
+	AppProtocol string
+	Mode        string
+}
 
+// servicePort retourne le port exposé par le Service (port publié, sinon port du conteneur)
+func (p portSpec) servicePort() int32 {
+	if p.Published > 0 {
+		return p.Published
+	}
+	return p.Target
+}
+
+// parsePortEntries normalise les ports d'un service. Les entrées sont soit des maps
+// produites par le parser docker (target, published, protocol...), soit des chaînes "8080:80".
+func parsePortEntries(ports interface{}) ([]portSpec, error) {
 	portSlice, ok := ports.([]interface{})
 	if !ok {
 		return nil, fmt.Errorf("invalid ports format")
 	}
 
-	for _, port := range portSlice {
-		portStr, ok := port.(string)
-		if !ok {
-			continue
-		}
-
-		containerPort, err := parsePortMapping(portStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid port format %s: %w", portStr, err)
+	var specs []portSpec
+	for i, port := range portSlice {
+		switch p := port.(type) {
+		case map[string]interface{}:
+			spec := portSpec{
+				Name:        stringValue(p["name"]),
+				Target:      int32Value(p["target"]),
+				Published:   int32Value(p["published"]),
+				Protocol:    strings.ToUpper(stringValue(p["protocol"])),
+				HostIP:      stringValue(p["host_ip"]),
+				AppProtocol: stringValue(p["app_protocol"]),
+				Mode:        stringValue(p["mode"]),
+			}
+			if spec.Target <= 0 {
+				return nil, fmt.Errorf("invalid target port in port definition %d", i)
+			}
+			if spec.Protocol == "" {
+				spec.Protocol = "TCP"
+			}
+			specs = append(specs, spec)
+		case string:
+			servicePort, err := parseServicePortMapping(p, i)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port format %s: %w", p, err)
+			}
+			target, _ := strconv.Atoi(servicePort.TargetPort)
+			specs = append(specs, portSpec{
+				Target:    int32(target),
+				Published: servicePort.Port,
+				Protocol:  servicePort.Protocol,
+			})
 		}
-
-		containerPorts = append(containerPorts, *containerPort)
 	}
 
-	return containerPorts, nil
+	assignPortNames(specs)
+
+	return specs, nil
 }
 
-// parsePortMapping parse une mapping de port Docker Compose
-func parsePortMapping(portMapping string) (*ContainerPort, error) {
-	parts := strings.Split(portMapping, ":")
+// assignPortNames attribue un nom unique (IANA_SVC_NAME, 15 caractères max) à chaque port
+func assignPortNames(specs []portSpec) {
+	used := make(map[string]bool)
+	for _, spec := range specs {
+		if spec.Name != "" {
+			used[spec.Name] = true
+		}
+	}
 
-	var containerPortNum int32
-	var protocol = "TCP"
+	for i := range specs {
+		if specs[i].Name != "" {
+			continue
+		}
 
-	switch len(parts) {
-	case 1:
-		// Format: "8080"
-		port, err := strconv.Atoi(parts[0])
-		if err != nil {
-			return nil, fmt.Errorf("invalid port number: %s", parts[0])
+		base := fmt.Sprintf("%s-%d", strings.ToLower(specs[i].Protocol), specs[i].Target)
+		name := base
+		if used[name] && specs[i].Published > 0 {
+			name = fmt.Sprintf("%s-%d", base, specs[i].Published)
 		}
-		containerPortNum = int32(port)
-	case 2:
-		// Format: "8080:80"
-		port, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return nil, fmt.Errorf("invalid container port number: %s", parts[1])
-		}
-		containerPortNum = int32(port)
-	case 3:
-		// Format: "127.0.0.1:8080:80" ou "8080:80:tcp"
-		if strings.Contains(parts[2], "tcp") || strings.Contains(parts[2], "udp") {
-			protocol = strings.ToUpper(parts[2])
-			port, err := strconv.Atoi(parts[1])
-			if err != nil {
-				return nil, fmt.Errorf("invalid container port number: %s", parts[1])
-			}
-			containerPortNum = int32(port)
-		} else {
-			port, err := strconv.Atoi(parts[2])
-			if err != nil {
-				return nil, fmt.Errorf("invalid container port number: %s", parts[2])
-			}
-			containerPortNum = int32(port)
+		for n := 2; used[name] || len(name) > 15; n++ {
+			name = truncatePortName(base, fmt.Sprintf("-%d", n))
 		}
-	default:
-		return nil, fmt.Errorf("invalid port mapping format: %s", portMapping)
+
+		used[name] = true
+		specs[i].Name = name
 	}
+}
 
-	return &ContainerPort{
-		ContainerPort: containerPortNum,
-		Protocol:      protocol,
-	}, nil
+// truncatePortName tronque un nom de port pour respecter la limite de 15 caractères
+func truncatePortName(base, suffix string) string {
+	if len(base)+len(suffix) > 15 {
+		base = base[:15-len(suffix)]
+	}
+	return strings.TrimSuffix(base, "-") + suffix
 }
 
-// generateEnvVars génère les variables d'environnement
-func generateEnvVars(env interface{}) ([]EnvVar, error) {
-	var envVars []EnvVar
+// generateContainerPorts génère les ports de conteneur
+func generateContainerPorts(ports interface{}) ([]ContainerPort, error) {
+	specs, err := parsePortEntries(ports)
+	if err != nil {
+		return nil, err
+	}
 
-	switch e := env.(type) {
-	case map[string]interface{}:
-		for key, value := range e {
-			envVars = append(envVars, EnvVar{
-				Name:  key,
-				Value: fmt.Sprintf("%v", value),
-			})
+	return containerPortsFromSpecs(specs), nil
+}
+
+// containerPortsFromSpecs génère un port de conteneur par couple port/protocole
+func containerPortsFromSpecs(specs []portSpec) []ContainerPort {
+	var containerPorts []ContainerPort
+	seen := make(map[string]bool)
+
+	for _, spec := range specs {
+		key := containerPortKey(spec)
+		if seen[key] {
+			continue
 		}
-	case map[string]string:
-		for key, value := range e {
-			envVars = append(envVars, EnvVar{
-				Name:  key,
-				Value: value,
-			})
+		seen[key] = true
+
+		containerPort := ContainerPort{
+			Name:          spec.Name,
+			ContainerPort: spec.Target,
+			Protocol:      spec.Protocol,
 		}
-	case []interface{}:
-		for _, item := range e {
-			envStr, ok := item.(string)
-			if !ok {
-				continue
-			}
-			parts := strings.SplitN(envStr, "=", 2)
-			if len(parts) == 2 {
-				envVars = append(envVars, EnvVar{
-					Name:  parts[0],
-					Value: parts[1],
-				})
-			} else {
-				envVars = append(envVars, EnvVar{
-					Name:  parts[0],
-					Value: "",
-				})
-			}
+
+		// Le mode "host" publie le port directement sur le nœud
+		if spec.Mode == "host" && spec.Published > 0 {
+			containerPort.HostPort = spec.Published
+			containerPort.HostIP = spec.HostIP
 		}
-	default:
-		return nil, fmt.Errorf("unsupported environment format: %T", env)
+
+		containerPorts = append(containerPorts, containerPort)
 	}
 
-	return envVars, nil
+	return containerPorts
 }
 
-// generateVolumes génère les volumes et volume mounts
-func generateVolumes(service map[string]interface{}) ([]Volume, []VolumeMount, error) {
-	var volumes []Volume
-	var volumeMounts []VolumeMount
+// containerPortKey identifie un port de conteneur par son numéro et son protocole
+func containerPortKey(spec portSpec) string {
+	return fmt.Sprintf("%d/%s", spec.Target, spec.Protocol)
+}
 
-	volumesConfig, ok := service["volumes"]
-	if !ok {
-		return volumes, volumeMounts, nil
+// generateEnvFrom référence la ConfigMap des variables non sensibles et le Secret des variables sensibles
+func generateEnvFrom(serviceName string, env interface{}, secretPatterns []string) ([]EnvFromSource, error) {
+	config, secrets, err := splitEnvironment(env, secretPatterns)
+	if err != nil {
+		return nil, err
 	}
 
-	volumeSlice, ok := volumesConfig.([]interface{})
-	if !ok {
-		return volumes, volumeMounts, nil
+	var envFrom []EnvFromSource
+	if len(config) > 0 {
+		envFrom = append(envFrom, EnvFromSource{
+			ConfigMapRef: &ConfigMapEnvSource{
+				LocalObjectReference: LocalObjectReference{Name: ConfigMapName(serviceName)},
+			},
+		})
+	}
+	if len(secrets) > 0 {
+		envFrom = append(envFrom, EnvFromSource{
+			SecretRef: &SecretEnvSource{
+				LocalObjectReference: LocalObjectReference{Name: SecretName(serviceName)},
+			},
+		})
 	}
 
-	for i, vol := range volumeSlice {
-		volStr, ok := vol.(string)
-		if !ok {
-			continue
-		}
-
-		volume, volumeMount, err := parseVolumeMapping(volStr, i)
-		if err != nil {
-			return nil, nil, fmt.Errorf("invalid volume mapping %s: %w", volStr, err)
-		}
+	return envFrom, nil
+}
 
-		volumes = append(volumes, *volume)
-		volumeMounts = append(volumeMounts, *volumeMount)
+// environmentChecksums calcule l'empreinte des données de la ConfigMap et du Secret d'un service.
+// Posées en annotations du template de pod, elles déclenchent un rollout quand la configuration change.
+func environmentChecksums(env interface{}, secretPatterns []string) (map[string]string, error) {
+	config, secrets, err := splitEnvironment(env, secretPatterns)
+	if err != nil {
+		return nil, err
 	}
 
-	return volumes, volumeMounts, nil
+	annotations := make(map[string]string)
+	if len(config) > 0 {
+		annotations[ConfigChecksumAnnotation] = dataChecksum(config)
+	}
+	if len(secrets) > 0 {
+		annotations[SecretChecksumAnnotation] = dataChecksum(secrets)
+	}
+	return annotations, nil
 }
 
-// parseVolumeMapping parse un mapping de volume Docker Compose
-func parseVolumeMapping(volumeMapping string, index int) (*Volume, *VolumeMount, error) {
-	parts := strings.Split(volumeMapping, ":")
-
-	var hostPath string
-	var containerPath string
-	readOnly := false
+// dataChecksum retourne l'empreinte SHA-256 d'un ensemble clé/valeur, indépendante de l'ordre des clés
+func dataChecksum(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-	switch len(parts) {
-	case 1:
-		// Single value. Treat it as a host path mounted at the same container path
-		hostPath = parts[0]
-		containerPath = parts[0]
-	default:
-		// Expected formats: host:container[:mode] or named-volume:container[:mode]
-		hostPath = parts[0]
-		containerPath = parts[1]
-		if len(parts) > 2 && parts[2] == "ro" {
-			readOnly = true
-		}
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, data[key])
 	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-	volumeName := fmt.Sprintf("volume-%d", index)
+// volumeSpec représente un montage normalisé issu de la définition Docker Compose
+type volumeSpec struct {
+	Type        string // volume, bind, tmpfs, npipe
+	Source      string
+	Target      string
+	ReadOnly    bool
+	Propagation string
+	Subpath     string
+	Size        string
+}
 
-	volume := &Volume{
-		Name: volumeName,
-		HostPath: &HostPathVolumeSource{
-			Path: hostPath,
-		},
+// parseVolumeEntries normalise les montages d'un service. Les entrées sont soit des maps
+// produites par le parser docker (type, source, target...), soit des chaînes "source:target[:mode]".
+func parseVolumeEntries(volumes interface{}) []volumeSpec {
+	volumeSlice, ok := volumes.([]interface{})
+	if !ok {
+		return nil
 	}
 
-	volumeMount := &VolumeMount{
-		Name:      volumeName,
-		MountPath: containerPath,
-		ReadOnly:  readOnly,
+	var specs []volumeSpec
+	for _, vol := range volumeSlice {
+		switch v := vol.(type) {
+		case map[string]interface{}:
+			readOnly, _ := v["read_only"].(bool)
+			specs = append(specs, volumeSpec{
+				Type:        stringValue(v["type"]),
+				Source:      stringValue(v["source"]),
+				Target:      stringValue(v["target"]),
+				ReadOnly:    readOnly,
+				Propagation: stringValue(v["propagation"]),
+				Subpath:     stringValue(v["subpath"]),
+				Size:        stringValue(v["size"]),
+			})
+		case string:
+			parts := strings.Split(v, ":")
+			spec := volumeSpec{Type: "volume", Target: parts[0]}
+			if len(parts) > 1 {
+				spec.Source = parts[0]
+				spec.Target = parts[1]
+				spec.ReadOnly = len(parts) > 2 && parts[2] == "ro"
+				if strings.HasPrefix(spec.Source, "/") || strings.HasPrefix(spec.Source, ".") || strings.HasPrefix(spec.Source, "~") {
+					spec.Type = "bind"
+				}
+			}
+			specs = append(specs, spec)
+		}
 	}
 
-	return volume, volumeMount, nil
+	return specs
 }
 
-// generateProbes génère les health check probes
-type probeSet struct {
-	liveness  *Probe
-	readiness *Probe
-}
+// generateVolumes génère les volumes et volume mounts. Chaque type de montage Compose
+// est associé à sa source Kubernetes : PVC pour les volumes nommés, emptyDir pour les
+// volumes anonymes et tmpfs, hostPath uniquement pour les bind mounts explicites.
+func generateVolumes(service map[string]interface{}) ([]Volume, []VolumeMount, error) {
+	var volumes []Volume
+	var volumeMounts []VolumeMount
 
-func generateProbes(healthcheck interface{}) (*probeSet, error) {
-	healthMap, ok := healthcheck.(map[string]interface{})
+	volumesConfig, ok := service["volumes"]
 	if !ok {
-		return &probeSet{}, nil
+		return volumes, volumeMounts, nil
 	}
 
-	var probes probeSet
+	declared := make(map[string]bool)
 
-	// Test command
-	if test, ok := healthMap["test"]; ok {
-		testCmd := normalizeStringSlice(test)
-		if len(testCmd) > 0 {
-			probe := &Probe{
-				Handler: Handler{
-					Exec: &ExecAction{
-						Command: testCmd,
-					},
-				},
-			}
+	for i, spec := range parseVolumeEntries(volumesConfig) {
+		if spec.Target == "" {
+			return nil, nil, fmt.Errorf("volume %d has no target path", i)
+		}
 
-			// Interval, timeout, etc.
-			if interval, ok := healthMap["interval"].(string); ok {
-				// Convertir duration string en secondes
-				if seconds, err := parseDurationToSeconds(interval); err == nil {
-					probe.PeriodSeconds = seconds
-				}
-			}
+		volume := Volume{Name: fmt.Sprintf("volume-%d", i)}
 
-			if timeout, ok := healthMap["timeout"].(string); ok {
-				if seconds, err := parseDurationToSeconds(timeout); err == nil {
-					probe.TimeoutSeconds = seconds
+		switch spec.Type {
+		case "volume":
+			if spec.Source != "" {
+				volume.Name = toDNSLabel(spec.Source)
+				volume.PersistentVolumeClaim = &PVCVolumeSource{
+					ClaimName: VolumeClaimName(spec.Source),
 				}
+			} else {
+				volume.EmptyDir = &EmptyDirVolumeSource{}
 			}
-
-			if retries, ok := healthMap["retries"].(int); ok {
-				probe.FailureThreshold = int32(retries)
+		case "tmpfs":
+			sizeLimit, err := toKubernetesQuantity(spec.Size)
+			if err != nil {
+				return nil, nil, fmt.Errorf("volume %d: %w", i, err)
 			}
+			volume.EmptyDir = &EmptyDirVolumeSource{
+				Medium:    "Memory",
+				SizeLimit: sizeLimit,
+			}
+		case "bind":
+			volume.HostPath = &HostPathVolumeSource{
+				Path: spec.Source,
+			}
+		default:
+			// npipe et types inconnus n'ont pas d'équivalent Kubernetes
+			continue
+		}
 
-			// Utiliser comme liveness et readiness probe
-			probes.liveness = probe
-			probes.readiness = &(*probe) // copie
+		// Un même volume nommé peut être monté plusieurs fois
+		if !declared[volume.Name] {
+			declared[volume.Name] = true
+			volumes = append(volumes, volume)
 		}
+
+		volumeMounts = append(volumeMounts, VolumeMount{
+			Name:             volume.Name,
+			MountPath:        spec.Target,
+			ReadOnly:         spec.ReadOnly,
+			SubPath:          spec.Subpath,
+			MountPropagation: mountPropagation(spec.Propagation),
+		})
 	}
 
-	return &probes, nil
+	return volumes, volumeMounts, nil
+}
+
+// mountPropagation convertit une propagation de bind mount Docker en propagation Kubernetes
+func mountPropagation(propagation string) string {
+	switch propagation {
+	case "shared", "rshared":
+		return "Bidirectional"
+	case "slave", "rslave":
+		return "HostToContainer"
+	case "private", "rprivate":
+		return "None"
+	default:
+		return ""
+	}
+}
+
+// containerCommand traduit entrypoint et command docker-compose en command et args Kubernetes.
+// Comme avec docker, un entrypoint défini ignore le CMD de l'image, et entrypoint: [] exécute
+// la command telle quelle sans l'ENTRYPOINT de l'image.
+func containerCommand(service map[string]interface{}) ([]string, []string) {
+	args := normalizeStringSlice(service["command"])
+	if len(args) == 0 {
+		args = nil
+	}
+
+	entrypoint, hasEntrypoint := service["entrypoint"]
+	if !hasEntrypoint {
+		return nil, args
+	}
+	if entrypointArgs := normalizeStringSlice(entrypoint); len(entrypointArgs) > 0 {
+		return entrypointArgs, args
+	}
+	return args, nil
 }
 
 // generateResourceRequirements génère les exigences de ressources
@@ -482,39 +689,6 @@ func generateResourceRequirements(resources map[string]interface{}) (*ResourceRe
 	return reqs, nil
 }
 
-// generateSecurityContext génère le contexte de sécurité
-func generateSecurityContext(service map[string]interface{}) (*SecurityContext, error) {
-	var securityContext *SecurityContext
-
-	// User
-	if user, ok := service["user"].(string); ok {
-		if securityContext == nil {
-			securityContext = &SecurityContext{}
-		}
-		if userID, err := strconv.ParseInt(user, 10, 64); err == nil {
-			securityContext.RunAsUser = &userID
-		}
-	}
-
-	// Privileged
-	if privileged, ok := service["privileged"].(bool); ok && privileged {
-		if securityContext == nil {
-			securityContext = &SecurityContext{}
-		}
-		securityContext.Privileged = &privileged
-	}
-
-	// Read-only root filesystem
-	if readOnly, ok := service["read_only"].(bool); ok && readOnly {
-		if securityContext == nil {
-			securityContext = &SecurityContext{}
-		}
-		securityContext.ReadOnlyRootFilesystem = &readOnly
-	}
-
-	return securityContext, nil
-}
-
 // Fonctions utilitaires
 
 func normalizeStringSlice(input interface{}) []string {
@@ -536,6 +710,77 @@ func normalizeStringSlice(input interface{}) []string {
 	}
 }
 
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func int32Value(value interface{}) int32 {
+	switch v := value.(type) {
+	case int:
+		return int32(v)
+	case int32:
+		return v
+	case int64:
+		return int32(v)
+	case float64:
+		return int32(v)
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return int32(n)
+		}
+	}
+	return 0
+}
+
+// toDNSLabel convertit un nom quelconque en label DNS-1123 valide (63 caractères max)
+func toDNSLabel(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	label := b.String()
+	if len(label) > 63 {
+		label = label[:63]
+	}
+	return strings.Trim(label, "-")
+}
+
+// toKubernetesQuantity convertit une taille Docker ("64m", "1g", "100b", "1048576") en quantité Kubernetes.
+// Le suffixe b des octets est retiré ; une taille qui ne se lit pas comme un nombre est une erreur.
+func toKubernetesQuantity(size string) (string, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return "", nil
+	}
+
+	lower := strings.TrimSuffix(strings.ToLower(size), "b")
+	number, unit := lower, ""
+	units := map[string]string{"k": "Ki", "m": "Mi", "g": "Gi", "t": "Ti"}
+	for suffix, kubernetesUnit := range units {
+		if strings.HasSuffix(lower, suffix) {
+			number, unit = strings.TrimSuffix(lower, suffix), kubernetesUnit
+			break
+		}
+	}
+
+	if value, err := strconv.ParseFloat(number, 64); err != nil || value < 0 || strings.ContainsAny(number, "eE+") {
+		return "", fmt.Errorf("invalid size %q", size)
+	}
+	return number + unit, nil
+}
+
 func mergeLabels(base, additional map[string]string) map[string]string {
 	result := make(map[string]string)
 	for k, v := range base {
@@ -547,33 +792,22 @@ func mergeLabels(base, additional map[string]string) map[string]string {
 	return result
 }
 
+// parseDurationToSeconds convertit une durée docker-compose ("30s", "1m30s", "1.5s") en secondes,
+// arrondie à la seconde supérieure ; un nombre sans unité est exprimé en secondes
 func parseDurationToSeconds(duration string) (int32, error) {
-	// Simple parser pour les durées comme "30s", "1m", etc.
 	duration = strings.TrimSpace(duration)
 	if duration == "" {
 		return 0, fmt.Errorf("empty duration")
 	}
 
-	var multiplier int32 = 1
-	var numStr string
-
-	if strings.HasSuffix(duration, "s") {
-		multiplier = 1
-		numStr = strings.TrimSuffix(duration, "s")
-	} else if strings.HasSuffix(duration, "m") {
-		multiplier = 60
-		numStr = strings.TrimSuffix(duration, "m")
-	} else if strings.HasSuffix(duration, "h") {
-		multiplier = 3600
-		numStr = strings.TrimSuffix(duration, "h")
-	} else {
-		numStr = duration
+	if seconds, err := strconv.ParseInt(duration, 10, 32); err == nil {
+		return int32(seconds), nil
 	}
 
-	num, err := strconv.ParseInt(numStr, 10, 32)
-	if err != nil {
+	parsed, err := time.ParseDuration(duration)
+	if err != nil || parsed < 0 {
 		return 0, fmt.Errorf("invalid duration format: %s", duration)
 	}
 
-	return int32(num) * multiplier, nil
+	return int32((parsed + time.Second - 1) / time.Second), nil
 }
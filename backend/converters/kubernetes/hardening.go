@@ -0,0 +1,257 @@
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Niveaux de durcissement, alignés sur les Pod Security Standards
+const (
+	HardeningNone       = "none"
+	HardeningBaseline   = "baseline"
+	HardeningRestricted = "restricted"
+)
+
+// DefaultHardeningScratchPaths répertoires inscriptibles (emptyDir) lorsque le système de fichiers
+// racine est en lecture seule
+var DefaultHardeningScratchPaths = []string{"/tmp"}
+
+// hardeningNonRootUser utilisateur des init containers générés, dont les images tournent en root
+const hardeningNonRootUser = int64(65534)
+
+// baselineCapabilities capabilities que le niveau baseline autorise à ajouter
+var baselineCapabilities = map[string]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true,
+	"KILL": true, "MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true,
+	"SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+}
+
+// baselineSELinuxTypes types SELinux autorisés par le niveau baseline
+var baselineSELinuxTypes = map[string]bool{
+	"container_t": true, "container_init_t": true, "container_kvm_t": true, "container_engine_t": true,
+}
+
+// HardeningOptions durcissement appliqué à chaque pod généré
+type HardeningOptions struct {
+	// Level none, baseline ou restricted
+	Level string `json:"level"`
+	// ScratchPaths répertoires montés en emptyDir au niveau restricted
+	ScratchPaths []string `json:"scratchPaths"`
+}
+
+// HardeningViolation réglage docker-compose contraire au niveau de durcissement demandé
+type HardeningViolation struct {
+	// Path chemin du réglage dans le service, par exemple ["volumes", "0"]
+	Path   []string
+	Reason string
+}
+
+// ValidateHardeningLevel vérifie que le niveau de durcissement est connu
+func ValidateHardeningLevel(level string) error {
+	switch level {
+	case "", HardeningNone, HardeningBaseline, HardeningRestricted:
+		return nil
+	}
+	return fmt.Errorf("unknown hardening level %q (expected %s, %s or %s)", level, HardeningNone, HardeningBaseline, HardeningRestricted)
+}
+
+// ValidateHardeningScratchPaths vérifie que les répertoires inscriptibles sont des chemins absolus
+// normalisés, autres que la racine que l'emptyDir masquerait entièrement
+func ValidateHardeningScratchPaths(paths []string) error {
+	for _, scratchPath := range paths {
+		switch {
+		case !path.IsAbs(scratchPath):
+			return fmt.Errorf("scratch path %q must be absolute", scratchPath)
+		case path.Clean(scratchPath) == "/":
+			return fmt.Errorf("scratch path %q cannot be the root directory", scratchPath)
+		case path.Clean(scratchPath) != scratchPath:
+			return fmt.Errorf("scratch path %q must be written as %q", scratchPath, path.Clean(scratchPath))
+		}
+	}
+	return nil
+}
+
+// CheckHardening liste les réglages du service qui empêcheraient l'admission de ses pods au niveau
+// demandé ; le durcissement ne les corrige pas puisqu'ils traduisent une intention explicite
+func CheckHardening(serviceMap map[string]interface{}, level string) []HardeningViolation {
+	if level != HardeningBaseline && level != HardeningRestricted {
+		return nil
+	}
+	restricted := level == HardeningRestricted
+
+	var violations []HardeningViolation
+
+	if privileged, ok := serviceMap["privileged"].(bool); ok && privileged {
+		violations = append(violations, HardeningViolation{Path: []string{"privileged"}, Reason: "privileged containers are not allowed"})
+	}
+
+	for i, spec := range parseVolumeEntries(serviceMap["volumes"]) {
+		if spec.Type == "bind" {
+			violations = append(violations, HardeningViolation{Path: []string{"volumes", strconv.Itoa(i)}, Reason: fmt.Sprintf("host path %s cannot be mounted", spec.Source)})
+		}
+	}
+
+	ports, _ := serviceMap["ports"].([]interface{})
+	for i, port := range ports {
+		if p, ok := port.(map[string]interface{}); ok && stringValue(p["mode"]) == "host" && int32Value(p["published"]) > 0 {
+			violations = append(violations, HardeningViolation{Path: []string{"ports", strconv.Itoa(i)}, Reason: "host ports are not allowed"})
+		}
+	}
+
+	for i, capability := range normalizeCapabilities(serviceMap["cap_add"]) {
+		allowed := baselineCapabilities[capability]
+		if restricted {
+			allowed = capability == "NET_BIND_SERVICE"
+		}
+		if !allowed {
+			violations = append(violations, HardeningViolation{Path: []string{"cap_add", strconv.Itoa(i)}, Reason: fmt.Sprintf("capability %s cannot be added", capability)})
+		}
+	}
+
+	for i, option := range normalizeStringSlice(serviceMap["security_opt"]) {
+		var container SecurityContext
+		applySecurityOpt(&container, option)
+		if reason := securityOptViolation(container); reason != "" {
+			violations = append(violations, HardeningViolation{Path: []string{"security_opt", strconv.Itoa(i)}, Reason: reason})
+		}
+	}
+
+	if sysctls, ok := serviceMap["sysctls"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(sysctls) {
+			if !safeSysctls[name] {
+				violations = append(violations, HardeningViolation{Path: []string{"sysctls", name}, Reason: fmt.Sprintf("unsafe sysctl %s is not allowed", name)})
+			}
+		}
+	}
+
+	if restricted {
+		if container, _, _ := ResolveSecurity(serviceMap); container != nil && container.RunAsUser != nil && *container.RunAsUser == 0 {
+			violations = append(violations, HardeningViolation{Path: []string{"user"}, Reason: "containers cannot run as root"})
+		}
+	}
+
+	return violations
+}
+
+// securityOptViolation retourne la raison pour laquelle une option security_opt est interdite au niveau baseline
+func securityOptViolation(container SecurityContext) string {
+	switch {
+	case container.SeccompProfile != nil && container.SeccompProfile.Type == "Unconfined":
+		return "the seccomp profile cannot be unconfined"
+	case container.AppArmorProfile != nil && container.AppArmorProfile.Type == "Unconfined":
+		return "the AppArmor profile cannot be unconfined"
+	case container.SELinuxOptions != nil && (container.SELinuxOptions.User != "" || container.SELinuxOptions.Role != ""):
+		return "the SELinux user and role cannot be set"
+	case container.SELinuxOptions != nil && container.SELinuxOptions.Type != "" && !baselineSELinuxTypes[container.SELinuxOptions.Type]:
+		return fmt.Sprintf("SELinux type %s is not allowed", container.SELinuxOptions.Type)
+	}
+	return ""
+}
+
+// hardenPodSpec complète la spec du pod pour satisfaire le niveau de durcissement. Les deux niveaux
+// appliquent le profil seccomp du runtime, comme Docker par défaut ; restricted impose en plus un
+// utilisateur non root, retire toutes les capabilities hormis NET_BIND_SERVICE, interdit l'escalade
+// de privilèges et passe le système de fichiers racine en lecture seule avec des répertoires emptyDir.
+func hardenPodSpec(spec *PodSpec, options HardeningOptions) {
+	if options.Level != HardeningBaseline && options.Level != HardeningRestricted {
+		return
+	}
+
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &PodSecurityContext{}
+	}
+	if spec.SecurityContext.SeccompProfile == nil {
+		spec.SecurityContext.SeccompProfile = &SeccompProfile{Type: "RuntimeDefault"}
+	}
+
+	if options.Level != HardeningRestricted {
+		return
+	}
+
+	runAsNonRoot := true
+	spec.SecurityContext.RunAsNonRoot = &runAsNonRoot
+
+	for i := range spec.InitContainers {
+		restrictContainer(&spec.InitContainers[i])
+		if spec.InitContainers[i].SecurityContext.RunAsUser == nil {
+			user := hardeningNonRootUser
+			spec.InitContainers[i].SecurityContext.RunAsUser = &user
+		}
+	}
+
+	// Les chemins invalides sont signalés par ValidateHardeningScratchPaths et ignorés ici
+	var scratchPaths []string
+	if ValidateHardeningScratchPaths(options.ScratchPaths) == nil {
+		scratchPaths = options.ScratchPaths
+	}
+	names := scratchVolumeNames(scratchPaths)
+
+	for i := range spec.Containers {
+		restrictContainer(&spec.Containers[i])
+		for j, scratchPath := range scratchPaths {
+			if hasMountAt(spec.Containers[i], scratchPath) {
+				continue
+			}
+			name := names[j]
+			if !hasVolume(*spec, name) {
+				spec.Volumes = append(spec.Volumes, Volume{Name: name, EmptyDir: &EmptyDirVolumeSource{}})
+			}
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, VolumeMount{Name: name, MountPath: scratchPath})
+		}
+	}
+}
+
+// restrictContainer applique au conteneur les exigences du niveau restricted
+func restrictContainer(container *Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+
+	var add []string
+	if securityContext.Capabilities != nil && containsString(securityContext.Capabilities.Add, "NET_BIND_SERVICE") {
+		add = []string{"NET_BIND_SERVICE"}
+	}
+	securityContext.Capabilities = &Capabilities{Add: add, Drop: []string{"ALL"}}
+
+	allowPrivilegeEscalation, readOnly := false, true
+	securityContext.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	securityContext.ReadOnlyRootFilesystem = &readOnly
+}
+
+// scratchVolumeNames nomme le volume emptyDir de chaque répertoire inscriptible. Deux chemins dont le
+// nom DNS est identique (/var/cache et /var-cache) reçoivent l'index du chemin en suffixe.
+func scratchVolumeNames(paths []string) []string {
+	names := make([]string, len(paths))
+	used := make(map[string]bool, len(paths))
+	for i, scratchPath := range paths {
+		name := toDNSLabel("scratch-" + strings.Trim(scratchPath, "/"))
+		if used[name] {
+			suffix := "-" + strconv.Itoa(i)
+			name = strings.TrimRight(name[:min(len(name), 63-len(suffix))], "-") + suffix
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+func hasMountAt(container Container, path string) bool {
+	for _, mount := range container.VolumeMounts {
+		if strings.TrimSuffix(mount.MountPath, "/") == strings.TrimSuffix(path, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(spec PodSpec, name string) bool {
+	for _, volume := range spec.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
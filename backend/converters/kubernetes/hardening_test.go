@@ -0,0 +1,157 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardenPodSpecRestricted(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Hardening.Level = HardeningRestricted
+	options.Hardening.ScratchPaths = []string{"/tmp", "/var/cache/nginx"}
+
+	template, err := generatePodTemplate("web", map[string]interface{}{
+		"image":   "nginx",
+		"user":    "101",
+		"cap_add": []interface{}{"NET_BIND_SERVICE"},
+		"volumes": []interface{}{map[string]interface{}{"type": "tmpfs", "target": "/tmp"}},
+		"depends_on": map[string]interface{}{
+			"db": map[string]interface{}{"condition": DependencyServiceStarted, "kind": WorkloadDeployment, "ports": []interface{}{"5432"}},
+		},
+	}, options)
+	require.NoError(t, err)
+
+	runAsNonRoot := true
+	assert.Equal(t, &PodSecurityContext{
+		RunAsNonRoot:   &runAsNonRoot,
+		SeccompProfile: &SeccompProfile{Type: "RuntimeDefault"},
+	}, template.Spec.SecurityContext)
+
+	uid, allowPrivilegeEscalation, readOnly := int64(101), false, true
+	container := template.Spec.Containers[0]
+	assert.Equal(t, &SecurityContext{
+		Capabilities:             &Capabilities{Add: []string{"NET_BIND_SERVICE"}, Drop: []string{"ALL"}},
+		RunAsUser:                &uid,
+		ReadOnlyRootFilesystem:   &readOnly,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+	}, container.SecurityContext)
+
+	// /tmp est déjà un tmpfs : seul le second répertoire reçoit un emptyDir
+	require.Len(t, container.VolumeMounts, 2)
+	assert.Equal(t, VolumeMount{Name: "scratch-var-cache-nginx", MountPath: "/var/cache/nginx"}, container.VolumeMounts[1])
+	assert.Equal(t, Volume{Name: "scratch-var-cache-nginx", EmptyDir: &EmptyDirVolumeSource{}}, template.Spec.Volumes[1])
+
+	// Les init containers d'attente tournent en utilisateur non root
+	require.Len(t, template.Spec.InitContainers, 1)
+	initContext := template.Spec.InitContainers[0].SecurityContext
+	require.NotNil(t, initContext.RunAsUser)
+	assert.Equal(t, hardeningNonRootUser, *initContext.RunAsUser)
+	assert.Equal(t, []string{"ALL"}, initContext.Capabilities.Drop)
+}
+
+func TestHardenPodSpecBaseline(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Hardening.Level = HardeningBaseline
+
+	template, err := generatePodTemplate("web", map[string]interface{}{
+		"image":        "nginx",
+		"security_opt": []interface{}{"seccomp=profiles/strict.json"},
+	}, options)
+	require.NoError(t, err)
+
+	// Le profil seccomp du pod s'applique, celui du conteneur reste prioritaire
+	assert.Equal(t, &PodSecurityContext{SeccompProfile: &SeccompProfile{Type: "RuntimeDefault"}}, template.Spec.SecurityContext)
+	assert.Equal(t, &SecurityContext{SeccompProfile: &SeccompProfile{Type: "Localhost", LocalhostProfile: "strict.json"}}, template.Spec.Containers[0].SecurityContext)
+	assert.Empty(t, template.Spec.Volumes)
+
+	// Sans durcissement, la spec n'est pas modifiée
+	template, err = generatePodTemplate("web", map[string]interface{}{"image": "nginx"}, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Nil(t, template.Spec.SecurityContext)
+	assert.Nil(t, template.Spec.Containers[0].SecurityContext)
+}
+
+func TestHardenPodSpecScratchPaths(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Hardening.Level = HardeningRestricted
+
+	// Deux chemins de même nom DNS reçoivent chacun leur emptyDir
+	options.Hardening.ScratchPaths = []string{"/var/cache", "/var-cache"}
+	template, err := generatePodTemplate("web", map[string]interface{}{"image": "nginx"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, []Volume{
+		{Name: "scratch-var-cache", EmptyDir: &EmptyDirVolumeSource{}},
+		{Name: "scratch-var-cache-1", EmptyDir: &EmptyDirVolumeSource{}},
+	}, template.Spec.Volumes)
+	assert.Equal(t, []VolumeMount{
+		{Name: "scratch-var-cache", MountPath: "/var/cache"},
+		{Name: "scratch-var-cache-1", MountPath: "/var-cache"},
+	}, template.Spec.Containers[0].VolumeMounts)
+
+	// La racine n'est jamais masquée par un emptyDir
+	options.Hardening.ScratchPaths = []string{"/"}
+	template, err = generatePodTemplate("web", map[string]interface{}{"image": "nginx"}, options)
+	require.NoError(t, err)
+	assert.Empty(t, template.Spec.Volumes)
+	assert.Empty(t, template.Spec.Containers[0].VolumeMounts)
+}
+
+func TestValidateHardeningScratchPaths(t *testing.T) {
+	assert.NoError(t, ValidateHardeningScratchPaths([]string{"/tmp", "/var/cache/nginx"}))
+	for _, scratchPath := range []string{"/", "//", "tmp", "/var/cache/", "/var/../tmp"} {
+		assert.Error(t, ValidateHardeningScratchPaths([]string{scratchPath}), scratchPath)
+	}
+}
+
+func TestCheckHardening(t *testing.T) {
+	service := map[string]interface{}{
+		"user":         "0",
+		"privileged":   true,
+		"volumes":      []interface{}{"data:/data", "/var/run/docker.sock:/var/run/docker.sock"},
+		"ports":        []interface{}{"80:80", map[string]interface{}{"target": 9000, "published": 9000, "mode": "host"}},
+		"cap_add":      []interface{}{"CHOWN", "NET_ADMIN"},
+		"security_opt": []interface{}{"no-new-privileges", "apparmor=unconfined", "label=type:spc_t"},
+		"sysctls":      map[string]interface{}{"net.ipv4.tcp_syncookies": "1", "net.core.somaxconn": "1024"},
+	}
+
+	paths := func(violations []HardeningViolation) [][]string {
+		var result [][]string
+		for _, violation := range violations {
+			result = append(result, violation.Path)
+		}
+		return result
+	}
+
+	assert.Equal(t, [][]string{
+		{"privileged"},
+		{"volumes", "1"},
+		{"ports", "1"},
+		{"cap_add", "1"},
+		{"security_opt", "1"},
+		{"security_opt", "2"},
+		{"sysctls", "net.core.somaxconn"},
+	}, paths(CheckHardening(service, HardeningBaseline)))
+
+	assert.Equal(t, [][]string{
+		{"privileged"},
+		{"volumes", "1"},
+		{"ports", "1"},
+		{"cap_add", "0"},
+		{"cap_add", "1"},
+		{"security_opt", "1"},
+		{"security_opt", "2"},
+		{"sysctls", "net.core.somaxconn"},
+		{"user"},
+	}, paths(CheckHardening(service, HardeningRestricted)))
+
+	assert.Empty(t, CheckHardening(service, HardeningNone))
+}
+
+func TestValidateHardeningLevel(t *testing.T) {
+	for _, level := range []string{"", HardeningNone, HardeningBaseline, HardeningRestricted} {
+		assert.NoError(t, ValidateHardeningLevel(level))
+	}
+	assert.Error(t, ValidateHardeningLevel("strict"))
+}
@@ -0,0 +1,295 @@
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IngressLabelPrefix espace de labels docker-compose dédié à l'exposition HTTP d'un service.
+// Clés reconnues : enabled, host (liste séparée par des virgules), path, port, tls-secret.
+const IngressLabelPrefix = "devops-converter.ingress."
+
+// Annotations cert-manager posées sur les Ingress exposés en TLS
+const (
+	CertManagerIssuerAnnotation        = "cert-manager.io/issuer"
+	CertManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// Modes d'exposition HTTP des services
+const (
+	ExposureModeIngress    = "ingress"
+	ExposureModeGatewayAPI = "gateway-api"
+)
+
+// IngressOptions options d'exposition HTTP des services
+type IngressOptions struct {
+	Enabled bool `json:"enabled"`
+	// Mode génère des Ingress (ingress, par défaut) ou des HTTPRoute Gateway API (gateway-api)
+	Mode string `json:"mode"`
+	// Domain domaine de base : un service sans hôte explicite est exposé sur <service>.<domain>
+	Domain string `json:"domain"`
+	// Hosts hôte explicite par service
+	Hosts      map[string]string `json:"hosts"`
+	ClassName  string            `json:"className"`
+	TLSSecret  string            `json:"tlsSecret"`
+	PathPrefix string            `json:"pathPrefix"`
+	// CertManagerIssuer et CertManagerClusterIssuer activent TLS avec un certificat émis par cert-manager
+	CertManagerIssuer        string `json:"certManagerIssuer"`
+	CertManagerClusterIssuer string `json:"certManagerClusterIssuer"`
+
+	// GatewayName et GatewayNamespace désignent la Gateway à laquelle les HTTPRoute sont rattachées
+	GatewayName      string `json:"gatewayName"`
+	GatewayNamespace string `json:"gatewayNamespace"`
+	// GatewayClassName génère la Gateway elle-même avec cette classe
+	GatewayClassName string `json:"gatewayClassName"`
+}
+
+// httpExposure décrit comment un service est exposé en HTTP (hôtes, chemin, port, TLS)
+type httpExposure struct {
+	Hosts     []string
+	Path      string
+	PathType  string
+	Port      int32
+	TLS       bool
+	TLSSecret string
+}
+
+var (
+	traefikRouterRule  = regexp.MustCompile(`^traefik\.http\.routers\.([^.]+)\.rule$`)
+	traefikRouterTLS   = regexp.MustCompile(`^traefik\.http\.routers\.([^.]+)\.tls(\.certresolver)?$`)
+	traefikServicePort = regexp.MustCompile(`^traefik\.http\.services\.[^.]+\.loadbalancer\.server\.port$`)
+	traefikHostMatcher = regexp.MustCompile(`Host\(([^)]*)\)`)
+	traefikPathMatcher = regexp.MustCompile(`(PathPrefix|Path)\(\s*[` + "`" + `"']([^` + "`" + `"']+)[` + "`" + `"']`)
+	traefikQuoted      = regexp.MustCompile("[`\"']([^`\"']+)[`\"']")
+)
+
+// GenerateIngress génère l'Ingress d'un service exposé en HTTP, ou nil si le service n'est pas exposé
+func GenerateIngress(serviceName string, service interface{}, options GeneratorOptions) (*Ingress, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	if options.Ingress.Mode != "" && options.Ingress.Mode != ExposureModeIngress {
+		return nil, nil
+	}
+
+	exposure, err := resolveHTTPExposure(serviceName, serviceMap, options)
+	if err != nil || exposure == nil {
+		return nil, err
+	}
+
+	backend := IngressBackend{
+		Service: &IngressServiceBackend{
+			Name: serviceName,
+			Port: ServiceBackendPort{Number: exposure.Port},
+		},
+	}
+
+	ingress := &Ingress{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "Ingress",
+		Metadata: Metadata{
+			Name:      fmt.Sprintf("%s-ingress", serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: IngressSpec{
+			IngressClassName: options.Ingress.ClassName,
+		},
+	}
+
+	for _, host := range exposure.Hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, IngressRule{
+			Host: host,
+			HTTP: &HTTPIngressRuleValue{
+				Paths: []HTTPIngressPath{{
+					Path:     exposure.Path,
+					PathType: exposure.PathType,
+					Backend:  backend,
+				}},
+			},
+		})
+	}
+
+	if exposure.TLS {
+		ingress.Spec.TLS = []IngressTLS{{Hosts: exposure.Hosts, SecretName: exposure.TLSSecret}}
+
+		annotations := make(map[string]string)
+		if options.Ingress.CertManagerClusterIssuer != "" {
+			annotations[CertManagerClusterIssuerAnnotation] = options.Ingress.CertManagerClusterIssuer
+		} else if options.Ingress.CertManagerIssuer != "" {
+			annotations[CertManagerIssuerAnnotation] = options.Ingress.CertManagerIssuer
+		}
+		if len(annotations) > 0 {
+			ingress.Metadata.Annotations = annotations
+		}
+	}
+
+	return ingress, nil
+}
+
+// resolveHTTPExposure détermine l'exposition HTTP d'un service à partir des options et de ses labels.
+// Priorité : labels dédiés, labels Traefik, options hosts/domain, puis <service>.local.
+func resolveHTTPExposure(serviceName string, serviceMap map[string]interface{}, options GeneratorOptions) (*httpExposure, error) {
+	if !options.Ingress.Enabled {
+		return nil, nil
+	}
+
+	labels, _ := serviceMap["labels"].(map[string]string)
+	if value, ok := labels[IngressLabelPrefix+"enabled"]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil && !enabled {
+			return nil, nil
+		}
+	}
+	if value, ok := labels["traefik.enable"]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil && !enabled {
+			return nil, nil
+		}
+	}
+
+	traefik := parseTraefikLabels(labels)
+	exposure := &httpExposure{PathType: "Prefix"}
+
+	// Port exposé par le Service : sans port publié, le service n'a pas de Service à cibler
+	ports, ok := serviceMap["ports"].([]interface{})
+	if !ok || len(ports) == 0 {
+		return nil, nil
+	}
+	specs, err := parsePortEntries(ports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ports for %s: %w", serviceName, err)
+	}
+	targetPort := traefik.port
+	if value, ok := labels[IngressLabelPrefix+"port"]; ok {
+		port, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %sport label value %q", IngressLabelPrefix, value)
+		}
+		targetPort = int32(port)
+	}
+	for _, spec := range specs {
+		if spec.Protocol != "TCP" {
+			continue
+		}
+		if targetPort > 0 && spec.Target == targetPort {
+			exposure.Port = spec.servicePort()
+			break
+		}
+		if targetPort == 0 && isHTTPPort(int(spec.Target)) {
+			exposure.Port = spec.servicePort()
+			break
+		}
+	}
+	if exposure.Port == 0 {
+		if targetPort > 0 {
+			return nil, fmt.Errorf("port %d selected by labels is not published by service %s", targetPort, serviceName)
+		}
+		// Pas de port HTTP trouvé
+		return nil, nil
+	}
+
+	// Hôtes
+	switch {
+	case labels[IngressLabelPrefix+"host"] != "":
+		for _, host := range strings.Split(labels[IngressLabelPrefix+"host"], ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				exposure.Hosts = append(exposure.Hosts, host)
+			}
+		}
+	case len(traefik.hosts) > 0:
+		exposure.Hosts = traefik.hosts
+	case options.Ingress.Hosts[serviceName] != "":
+		exposure.Hosts = []string{options.Ingress.Hosts[serviceName]}
+	case options.Ingress.Domain != "":
+		exposure.Hosts = []string{fmt.Sprintf("%s.%s", serviceName, strings.TrimPrefix(options.Ingress.Domain, "."))}
+	default:
+		exposure.Hosts = []string{fmt.Sprintf("%s.local", serviceName)}
+	}
+
+	// Chemin, préfixé par l'option pathPrefix
+	servicePath := "/"
+	switch {
+	case labels[IngressLabelPrefix+"path"] != "":
+		servicePath = labels[IngressLabelPrefix+"path"]
+	case traefik.path != "":
+		servicePath = traefik.path
+		if traefik.exact {
+			exposure.PathType = "Exact"
+		}
+	}
+	exposure.Path = path.Join("/", options.Ingress.PathPrefix, servicePath)
+
+	// TLS : secret explicite, label Traefik ou certificat cert-manager
+	exposure.TLSSecret = options.Ingress.TLSSecret
+	if secret := labels[IngressLabelPrefix+"tls-secret"]; secret != "" {
+		exposure.TLSSecret = secret
+	}
+	certManager := options.Ingress.CertManagerIssuer != "" || options.Ingress.CertManagerClusterIssuer != ""
+	exposure.TLS = exposure.TLSSecret != "" || certManager || traefik.tls
+	if exposure.TLS && exposure.TLSSecret == "" {
+		exposure.TLSSecret = toDNSLabel(serviceName + "-tls")
+	}
+
+	return exposure, nil
+}
+
+// traefikRoute règle de routage extraite des labels Traefik d'un service
+type traefikRoute struct {
+	hosts []string
+	path  string
+	exact bool
+	tls   bool
+	port  int32
+}
+
+// parseTraefikLabels extrait hôtes, chemin, TLS et port des labels Traefik v2/v3.
+// Seul le premier routeur (par ordre alphabétique) portant une règle est pris en compte.
+func parseTraefikLabels(labels map[string]string) traefikRoute {
+	var route traefikRoute
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	router := ""
+	for _, key := range keys {
+		match := traefikRouterRule.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		router = match[1]
+		rule := labels[key]
+		for _, hostMatch := range traefikHostMatcher.FindAllStringSubmatch(rule, -1) {
+			for _, quoted := range traefikQuoted.FindAllStringSubmatch(hostMatch[1], -1) {
+				route.hosts = append(route.hosts, quoted[1])
+			}
+		}
+		if pathMatch := traefikPathMatcher.FindStringSubmatch(rule); pathMatch != nil {
+			route.path = pathMatch[2]
+			route.exact = pathMatch[1] == "Path"
+		}
+		break
+	}
+
+	for _, key := range keys {
+		if match := traefikRouterTLS.FindStringSubmatch(key); match != nil && match[1] == router {
+			if match[2] != "" || labels[key] == "true" {
+				route.tls = true
+			}
+		}
+		if traefikServicePort.MatchString(key) {
+			if port, err := strconv.Atoi(strings.TrimSpace(labels[key])); err == nil {
+				route.port = int32(port)
+			}
+		}
+	}
+
+	return route
+}
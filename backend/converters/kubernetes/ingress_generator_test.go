@@ -0,0 +1,158 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ingressOptions(ingress IngressOptions) GeneratorOptions {
+	options := DefaultGeneratorOptions()
+	ingress.Enabled = true
+	options.Ingress = ingress
+	return options
+}
+
+func TestGenerateIngress(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "web",
+		"ports": []interface{}{"5432:5432", "8081:80"},
+	}
+
+	// Désactivé par défaut
+	ingress, err := GenerateIngress("web", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Nil(t, ingress)
+
+	ingress, err = GenerateIngress("web", service, ingressOptions(IngressOptions{
+		Domain:                   "example.com",
+		ClassName:                "nginx",
+		PathPrefix:               "/app",
+		CertManagerClusterIssuer: "letsencrypt",
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, ingress)
+
+	assert.Equal(t, "web-ingress", ingress.GetName())
+	assert.Equal(t, "networking.k8s.io/v1", ingress.APIVersion)
+	assert.Equal(t, "nginx", ingress.Spec.IngressClassName)
+	require.Len(t, ingress.Spec.Rules, 1)
+	rule := ingress.Spec.Rules[0]
+	assert.Equal(t, "web.example.com", rule.Host)
+	assert.Equal(t, "/app", rule.HTTP.Paths[0].Path)
+	assert.Equal(t, "Prefix", rule.HTTP.Paths[0].PathType)
+	assert.Equal(t, int32(8081), rule.HTTP.Paths[0].Backend.Service.Port.Number)
+	assert.Equal(t, []IngressTLS{{Hosts: []string{"web.example.com"}, SecretName: "web-tls"}}, ingress.Spec.TLS)
+	assert.Equal(t, map[string]string{CertManagerClusterIssuerAnnotation: "letsencrypt"}, ingress.Metadata.Annotations)
+
+	// Sans port HTTP, aucun Ingress n'est généré
+	ingress, err = GenerateIngress("db", map[string]interface{}{"ports": []interface{}{"5432"}}, ingressOptions(IngressOptions{}))
+	require.NoError(t, err)
+	assert.Nil(t, ingress)
+}
+
+func TestGenerateIngressFromLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		options   IngressOptions
+		hosts     []string
+		path      string
+		pathType  string
+		port      int32
+		tlsSecret string
+	}{
+		{
+			name:     "options host",
+			options:  IngressOptions{Hosts: map[string]string{"api": "api.internal"}, Domain: "example.com"},
+			hosts:    []string{"api.internal"},
+			path:     "/",
+			pathType: "Prefix",
+			port:     8000,
+		},
+		{
+			name: "traefik router",
+			labels: map[string]string{
+				"traefik.enable":                                     "true",
+				"traefik.http.routers.api.rule":                      "Host(`api.example.com`, `www.example.com`) && Path(`/v1`)",
+				"traefik.http.routers.api.tls.certresolver":          "le",
+				"traefik.http.services.api.loadbalancer.server.port": "9100",
+			},
+			options:   IngressOptions{Domain: "example.com"},
+			hosts:     []string{"api.example.com", "www.example.com"},
+			path:      "/v1",
+			pathType:  "Exact",
+			port:      9100,
+			tlsSecret: "api-tls",
+		},
+		{
+			name: "dedicated labels",
+			labels: map[string]string{
+				IngressLabelPrefix + "host":       "public.example.com",
+				IngressLabelPrefix + "path":       "/api",
+				IngressLabelPrefix + "tls-secret": "public-cert",
+				"traefik.http.routers.api.rule":   "Host(`ignored.example.com`)",
+			},
+			options:   IngressOptions{PathPrefix: "/edge"},
+			hosts:     []string{"public.example.com"},
+			path:      "/edge/api",
+			pathType:  "Prefix",
+			port:      8000,
+			tlsSecret: "public-cert",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := map[string]interface{}{
+				"image":  "api",
+				"ports":  []interface{}{"8000", "9100"},
+				"labels": tt.labels,
+			}
+			if tt.labels == nil {
+				delete(service, "labels")
+			}
+
+			ingress, err := GenerateIngress("api", service, ingressOptions(tt.options))
+			require.NoError(t, err)
+			require.NotNil(t, ingress)
+
+			var hosts []string
+			for _, rule := range ingress.Spec.Rules {
+				hosts = append(hosts, rule.Host)
+				assert.Equal(t, tt.path, rule.HTTP.Paths[0].Path)
+				assert.Equal(t, tt.pathType, rule.HTTP.Paths[0].PathType)
+				assert.Equal(t, tt.port, rule.HTTP.Paths[0].Backend.Service.Port.Number)
+			}
+			assert.Equal(t, tt.hosts, hosts)
+
+			if tt.tlsSecret == "" {
+				assert.Empty(t, ingress.Spec.TLS)
+			} else {
+				require.Len(t, ingress.Spec.TLS, 1)
+				assert.Equal(t, tt.tlsSecret, ingress.Spec.TLS[0].SecretName)
+			}
+		})
+	}
+}
+
+func TestGenerateIngressDisabledByLabel(t *testing.T) {
+	for _, labels := range []map[string]string{
+		{"traefik.enable": "false"},
+		{IngressLabelPrefix + "enabled": "false"},
+	} {
+		ingress, err := GenerateIngress("web", map[string]interface{}{
+			"ports":  []interface{}{"80"},
+			"labels": labels,
+		}, ingressOptions(IngressOptions{}))
+		require.NoError(t, err)
+		assert.Nil(t, ingress)
+	}
+
+	_, err := GenerateIngress("web", map[string]interface{}{
+		"ports":  []interface{}{"80"},
+		"labels": map[string]string{IngressLabelPrefix + "port": "8443"},
+	}, ingressOptions(IngressOptions{}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Labels docker-compose décrivant la planification d'un CronJob (compatibles kompose)
+const (
+	CronJobScheduleLabel          = "kompose.cronjob.schedule"
+	CronJobConcurrencyPolicyLabel = "kompose.cronjob.concurrency_policy"
+	CronJobBackoffLimitLabel      = "kompose.cronjob.backoff_limit"
+)
+
+// isJobService indique si un service est une tâche ponctuelle : restart "no" ou "on-failure",
+// ou deploy.mode replicated-job
+func isJobService(serviceMap map[string]interface{}) bool {
+	restart := stringValue(serviceMap["restart"])
+	if restart == "no" || restart == "on-failure" || strings.HasPrefix(restart, "on-failure:") {
+		return true
+	}
+	if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok {
+		return stringValue(deploy["mode"]) == "replicated-job"
+	}
+	return false
+}
+
+// cronJobSchedule retourne la planification portée par les labels d'un service
+func cronJobSchedule(serviceMap map[string]interface{}) string {
+	labels, _ := serviceMap["labels"].(map[string]string)
+	return strings.TrimSpace(labels[CronJobScheduleLabel])
+}
+
+// GenerateJob génère un Job Kubernetes pour un service ponctuel (migration, seed...)
+func GenerateJob(serviceName string, service interface{}, options GeneratorOptions) (*Job, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	jobSpec, err := generateJobSpec(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		APIVersion: "batch/v1",
+		Kind:       "Job",
+		Metadata: Metadata{
+			Name:      serviceName,
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: *jobSpec,
+	}
+
+	return job, nil
+}
+
+// GenerateCronJob génère un CronJob Kubernetes pour un service portant le label de planification
+func GenerateCronJob(serviceName string, service interface{}, options GeneratorOptions) (*CronJob, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	schedule := cronJobSchedule(serviceMap)
+	if schedule == "" {
+		return nil, fmt.Errorf("service %s has no %s label", serviceName, CronJobScheduleLabel)
+	}
+
+	jobSpec, err := generateJobSpec(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, _ := serviceMap["labels"].(map[string]string)
+
+	if value, ok := labels[CronJobBackoffLimitLabel]; ok {
+		backoffLimit, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil || backoffLimit < 0 {
+			return nil, fmt.Errorf("invalid %s label value %q", CronJobBackoffLimitLabel, value)
+		}
+		limit := int32(backoffLimit)
+		jobSpec.BackoffLimit = &limit
+	}
+
+	cronJob := &CronJob{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Metadata: Metadata{
+			Name:      serviceName,
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: CronJobSpec{
+			Schedule:    schedule,
+			JobTemplate: JobTemplateSpec{Spec: *jobSpec},
+		},
+	}
+
+	if value, ok := labels[CronJobConcurrencyPolicyLabel]; ok {
+		switch policy := strings.ToLower(strings.TrimSpace(value)); policy {
+		case "allow", "forbid", "replace":
+			cronJob.Spec.ConcurrencyPolicy = strings.ToUpper(policy[:1]) + policy[1:]
+		default:
+			return nil, fmt.Errorf("invalid %s label value %q", CronJobConcurrencyPolicyLabel, value)
+		}
+	}
+
+	return cronJob, nil
+}
+
+// generateJobSpec génère la spec commune aux Jobs et aux CronJobs
+func generateJobSpec(serviceName string, serviceMap map[string]interface{}, options GeneratorOptions) (*JobSpec, error) {
+	template, err := generatePodTemplate(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	restartPolicy, backoffLimit, err := jobRestartPolicy(serviceMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid restart policy for %s: %w", serviceName, err)
+	}
+	template.Spec.RestartPolicy = restartPolicy
+
+	return &JobSpec{
+		BackoffLimit: backoffLimit,
+		Template:     *template,
+	}, nil
+}
+
+// jobRestartPolicy traduit la politique de redémarrage compose en restartPolicy et backoffLimit d'un Job.
+// deploy.restart_policy est prioritaire sur restart ; max_attempts (ou on-failure:N) fixe le backoffLimit.
+func jobRestartPolicy(serviceMap map[string]interface{}) (string, *int32, error) {
+	condition := stringValue(serviceMap["restart"])
+	var maxAttempts *int32
+
+	if name, attempts, ok := strings.Cut(condition, ":"); ok {
+		n, err := strconv.ParseInt(strings.TrimSpace(attempts), 10, 32)
+		if err != nil || n < 0 {
+			return "", nil, fmt.Errorf("invalid restart value %q", condition)
+		}
+		condition = name
+		limit := int32(n)
+		maxAttempts = &limit
+	}
+
+	if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok {
+		if restartPolicy, ok := deploy["restart_policy"].(map[string]interface{}); ok {
+			switch stringValue(restartPolicy["condition"]) {
+			case "none":
+				condition = "no"
+			case "on-failure", "any":
+				condition = "on-failure"
+			}
+			if _, ok := restartPolicy["max_attempts"]; ok {
+				limit := int32Value(restartPolicy["max_attempts"])
+				maxAttempts = &limit
+			}
+		}
+	}
+
+	switch condition {
+	case "no":
+		// Sans redémarrage, un échec termine le Job
+		if maxAttempts == nil {
+			zero := int32(0)
+			maxAttempts = &zero
+		}
+		return "Never", maxAttempts, nil
+	default:
+		return "OnFailure", maxAttempts, nil
+	}
+}
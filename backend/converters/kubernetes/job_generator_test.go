@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJob(t *testing.T) {
+	tests := []struct {
+		name          string
+		service       map[string]interface{}
+		restartPolicy string
+		backoffLimit  *int32
+	}{
+		{
+			name:          "restart no",
+			service:       map[string]interface{}{"image": "migrate", "restart": "no"},
+			restartPolicy: "Never",
+			backoffLimit:  int32Ptr(0),
+		},
+		{
+			name:          "on-failure with attempts",
+			service:       map[string]interface{}{"image": "migrate", "restart": "on-failure:3"},
+			restartPolicy: "OnFailure",
+			backoffLimit:  int32Ptr(3),
+		},
+		{
+			name: "deploy restart policy",
+			service: map[string]interface{}{
+				"image":   "migrate",
+				"restart": "on-failure",
+				"deploy": map[string]interface{}{
+					"restart_policy": map[string]interface{}{"condition": "on-failure", "max_attempts": 5},
+				},
+			},
+			restartPolicy: "OnFailure",
+			backoffLimit:  int32Ptr(5),
+		},
+		{
+			name:          "on-failure without attempts",
+			service:       map[string]interface{}{"image": "migrate", "restart": "on-failure"},
+			restartPolicy: "OnFailure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := ResolveWorkloadKind("migrate", tt.service, DefaultGeneratorOptions())
+			require.NoError(t, err)
+			require.Equal(t, WorkloadJob, kind)
+
+			job, err := GenerateJob("migrate", tt.service, DefaultGeneratorOptions())
+			require.NoError(t, err)
+			assert.Equal(t, "batch/v1", job.APIVersion)
+			assert.Equal(t, tt.restartPolicy, job.Spec.Template.Spec.RestartPolicy)
+			assert.Equal(t, tt.backoffLimit, job.Spec.BackoffLimit)
+		})
+	}
+}
+
+func TestGenerateCronJob(t *testing.T) {
+	service := map[string]interface{}{
+		"image":   "backup",
+		"restart": "always",
+		"labels": map[string]string{
+			CronJobScheduleLabel:          "0 3 * * *",
+			CronJobConcurrencyPolicyLabel: "forbid",
+			CronJobBackoffLimitLabel:      "2",
+		},
+	}
+
+	kind, err := ResolveWorkloadKind("backup", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	require.Equal(t, WorkloadCronJob, kind)
+
+	cronJob, err := GenerateCronJob("backup", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", cronJob.Spec.Schedule)
+	assert.Equal(t, "Forbid", cronJob.Spec.ConcurrencyPolicy)
+	assert.Equal(t, int32Ptr(2), cronJob.Spec.JobTemplate.Spec.BackoffLimit)
+	assert.Equal(t, "OnFailure", cronJob.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy)
+
+	service["labels"].(map[string]string)[CronJobConcurrencyPolicyLabel] = "sometimes"
+	_, err = GenerateCronJob("backup", service, DefaultGeneratorOptions())
+	assert.Error(t, err)
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
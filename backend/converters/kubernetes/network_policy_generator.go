@@ -0,0 +1,202 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// NetworkLabelPrefix préfixe des labels de pod portant l'appartenance aux réseaux docker-compose
+const NetworkLabelPrefix = "devops-converter.network/"
+
+// DefaultNetwork réseau implicite des services qui ne déclarent aucun réseau
+const DefaultNetwork = "default"
+
+// PublishedPortsSource pods autorisés à joindre les ports publiés des services, typiquement le
+// contrôleur d'ingress. Les deux critères se combinent ; des labels sans namespace sélectionnent
+// les pods portant ces labels dans tous les namespaces.
+type PublishedPortsSource struct {
+	// Namespace namespace des pods autorisés
+	Namespace string `json:"namespace"`
+	// PodLabels labels des pods autorisés
+	PodLabels map[string]string `json:"podLabels"`
+}
+
+// peer retourne le pair NetworkPolicy correspondant à la source, nil si elle n'est pas configurée
+func (s PublishedPortsSource) peer() *NetworkPolicyPeer {
+	if s.Namespace == "" && len(s.PodLabels) == 0 {
+		return nil
+	}
+	peer := &NetworkPolicyPeer{NamespaceSelector: &LabelSelector{}}
+	if s.Namespace != "" {
+		peer.NamespaceSelector.MatchLabels = map[string]string{"kubernetes.io/metadata.name": s.Namespace}
+	}
+	if len(s.PodLabels) > 0 {
+		peer.PodSelector = &LabelSelector{MatchLabels: s.PodLabels}
+	}
+	return peer
+}
+
+// NetworkLabel retourne le label de pod indiquant l'appartenance à un réseau docker-compose
+func NetworkLabel(network string) string {
+	return NetworkLabelPrefix + toDNSLabel(network)
+}
+
+// serviceNetworks retourne les réseaux d'un service triés par nom, ou le réseau par défaut
+func serviceNetworks(serviceMap map[string]interface{}) []string {
+	networks, _ := serviceMap["networks"].(map[string]interface{})
+	if len(networks) == 0 {
+		return []string{DefaultNetwork}
+	}
+
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// networkLabels retourne les labels de pod des réseaux d'un service
+func networkLabels(serviceMap map[string]interface{}) map[string]string {
+	labels := make(map[string]string)
+	for _, network := range serviceNetworks(serviceMap) {
+		labels[NetworkLabel(network)] = "true"
+	}
+	return labels
+}
+
+// GenerateNetworkPolicies traduit la segmentation des réseaux docker-compose en NetworkPolicies :
+//   - une policy par réseau n'autorise le trafic entrant que depuis les pods du même réseau ;
+//   - un réseau internal bloque le trafic sortant hors du namespace (hors DNS) pour les pods
+//     qui ne sont rattachés à aucun autre réseau ;
+//   - les ports publiés d'un service sont ouverts à la source options.PublishedPortsSource (le contrôleur
+//     d'ingress) lorsqu'elle est configurée ; sinon ils ne sont joignables que depuis les mêmes réseaux.
+//
+// networks associe le nom de chaque réseau de premier niveau à sa configuration (internal).
+func GenerateNetworkPolicies(services map[string]interface{}, networks map[string]interface{}, options GeneratorOptions) ([]*NetworkPolicy, error) {
+	serviceNames := make([]string, 0, len(services))
+	for name := range services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	// Réseaux effectivement utilisés par les services
+	used := make(map[string]bool)
+	for _, name := range serviceNames {
+		serviceMap, ok := services[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid service format for %s", name)
+		}
+		for _, network := range serviceNetworks(serviceMap) {
+			used[network] = true
+		}
+	}
+	networkNames := make([]string, 0, len(used))
+	for network := range used {
+		networkNames = append(networkNames, network)
+	}
+	sort.Strings(networkNames)
+
+	isInternal := func(network string) bool {
+		config, _ := networks[network].(map[string]interface{})
+		internal, _ := config["internal"].(bool)
+		return internal
+	}
+
+	var policies []*NetworkPolicy
+
+	for _, network := range networkNames {
+		members := &LabelSelector{MatchLabels: map[string]string{NetworkLabel(network): "true"}}
+
+		policies = append(policies, newNetworkPolicy(toDNSLabel(network+"-network"), options, NetworkPolicySpec{
+			PodSelector: *members,
+			PolicyTypes: []string{"Ingress"},
+			Ingress: []NetworkPolicyIngressRule{{
+				From: []NetworkPolicyPeer{{PodSelector: members}},
+			}},
+		}))
+
+		if !isInternal(network) {
+			continue
+		}
+
+		// Un pod également rattaché à un réseau non interne garde son accès sortant
+		selector := LabelSelector{MatchLabels: members.MatchLabels}
+		for _, other := range networkNames {
+			if !isInternal(other) {
+				selector.MatchExpressions = append(selector.MatchExpressions, LabelSelectorRequirement{
+					Key:      NetworkLabel(other),
+					Operator: "DoesNotExist",
+				})
+			}
+		}
+
+		dns := IntOrString("53")
+		policies = append(policies, newNetworkPolicy(toDNSLabel(network+"-network-egress"), options, NetworkPolicySpec{
+			PodSelector: selector,
+			PolicyTypes: []string{"Egress"},
+			Egress: []NetworkPolicyEgressRule{
+				{To: []NetworkPolicyPeer{{PodSelector: &LabelSelector{}}}},
+				{
+					To: []NetworkPolicyPeer{{NamespaceSelector: &LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"},
+					}}},
+					Ports: []NetworkPolicyPort{{Protocol: "UDP", Port: &dns}, {Protocol: "TCP", Port: &dns}},
+				},
+			},
+		}))
+	}
+
+	// Les ports publiés sont joignables depuis l'hôte en docker-compose : ils sont ouverts au contrôleur
+	// d'ingress uniquement, jamais à tous les namespaces
+	source := options.PublishedPortsSource.peer()
+	if source == nil {
+		return policies, nil
+	}
+	for _, name := range serviceNames {
+		serviceMap := services[name].(map[string]interface{})
+		ports, ok := serviceMap["ports"].([]interface{})
+		if !ok || len(ports) == 0 {
+			continue
+		}
+		specs, err := parsePortEntries(ports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ports for %s: %w", name, err)
+		}
+
+		var policyPorts []NetworkPolicyPort
+		seen := make(map[string]bool)
+		for _, spec := range specs {
+			key := containerPortKey(spec)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			port := IntOrString(strconv.Itoa(int(spec.Target)))
+			policyPorts = append(policyPorts, NetworkPolicyPort{Protocol: spec.Protocol, Port: &port})
+		}
+
+		policies = append(policies, newNetworkPolicy(toDNSLabel(name+"-published"), options, NetworkPolicySpec{
+			PodSelector: LabelSelector{MatchLabels: map[string]string{"app": name}},
+			PolicyTypes: []string{"Ingress"},
+			Ingress:     []NetworkPolicyIngressRule{{From: []NetworkPolicyPeer{*source}, Ports: policyPorts}},
+		}))
+	}
+
+	return policies, nil
+}
+
+// newNetworkPolicy construit une NetworkPolicy avec les métadonnées communes
+func newNetworkPolicy(name string, options GeneratorOptions, spec NetworkPolicySpec) *NetworkPolicy {
+	return &NetworkPolicy{
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+		Metadata: Metadata{
+			Name:      name,
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, nil),
+		},
+		Spec: spec,
+	}
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateNetworkPolicies(t *testing.T) {
+	services := map[string]interface{}{
+		"web": map[string]interface{}{
+			"image":    "nginx",
+			"ports":    []interface{}{"8080:80"},
+			"networks": map[string]interface{}{"front": map[string]interface{}{}, "back": map[string]interface{}{}},
+		},
+		"db": map[string]interface{}{
+			"image":    "postgres",
+			"networks": map[string]interface{}{"back": map[string]interface{}{}},
+		},
+		"worker": map[string]interface{}{"image": "worker"},
+	}
+	networks := map[string]interface{}{
+		"front": map[string]interface{}{"internal": false},
+		"back":  map[string]interface{}{"internal": true},
+	}
+
+	policies, err := GenerateNetworkPolicies(services, networks, DefaultGeneratorOptions())
+	require.NoError(t, err)
+
+	byName := make(map[string]*NetworkPolicy)
+	for _, policy := range policies {
+		byName[policy.GetName()] = policy
+	}
+	require.Len(t, byName, 4)
+
+	// Trafic entrant limité aux pods du même réseau
+	back := byName["back-network"]
+	require.NotNil(t, back)
+	assert.Equal(t, map[string]string{NetworkLabel("back"): "true"}, back.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []string{"Ingress"}, back.Spec.PolicyTypes)
+	assert.Equal(t, back.Spec.PodSelector.MatchLabels, back.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+	assert.NotNil(t, byName["default-network"])
+	assert.NotNil(t, byName["front-network"])
+
+	// Réseau interne : trafic sortant limité au namespace pour les pods sans autre réseau
+	egress := byName["back-network-egress"]
+	require.NotNil(t, egress)
+	assert.Equal(t, []string{"Egress"}, egress.Spec.PolicyTypes)
+	assert.Equal(t, []LabelSelectorRequirement{
+		{Key: NetworkLabel("default"), Operator: "DoesNotExist"},
+		{Key: NetworkLabel("front"), Operator: "DoesNotExist"},
+	}, egress.Spec.PodSelector.MatchExpressions)
+	require.Len(t, egress.Spec.Egress, 2)
+	assert.Equal(t, &LabelSelector{}, egress.Spec.Egress[0].To[0].PodSelector)
+
+	// Sans source configurée, les ports publiés ne sont pas ouverts hors des réseaux
+	assert.Nil(t, byName["web-published"])
+
+	// Les ports publiés ne sont ouverts qu'au contrôleur d'ingress
+	options := DefaultGeneratorOptions()
+	options.PublishedPortsSource = PublishedPortsSource{Namespace: "ingress-nginx", PodLabels: map[string]string{"app.kubernetes.io/name": "ingress-nginx"}}
+	policies, err = GenerateNetworkPolicies(services, networks, options)
+	require.NoError(t, err)
+	require.Len(t, policies, 5)
+	published := policies[4]
+	assert.Equal(t, "web-published", published.GetName())
+	assert.Equal(t, []NetworkPolicyPeer{{
+		NamespaceSelector: &LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}},
+		PodSelector:       &LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "ingress-nginx"}},
+	}}, published.Spec.Ingress[0].From)
+	assert.Equal(t, IntOrString("80"), *published.Spec.Ingress[0].Ports[0].Port)
+
+	// Des labels seuls sélectionnent les pods de tous les namespaces
+	options.PublishedPortsSource = PublishedPortsSource{PodLabels: map[string]string{"app": "traefik"}}
+	policies, err = GenerateNetworkPolicies(services, networks, options)
+	require.NoError(t, err)
+	assert.Equal(t, &LabelSelector{}, policies[4].Spec.Ingress[0].From[0].NamespaceSelector)
+}
+
+func TestNetworkLabelsOnPodTemplate(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.NetworkPolicies = true
+
+	deployment, err := GenerateDeployment("db", map[string]interface{}{
+		"image":    "postgres",
+		"networks": map[string]interface{}{"back_end": map[string]interface{}{}},
+	}, options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "db", "devops-converter.network/back-end": "true"}, deployment.Spec.Template.Metadata.Labels)
+	assert.Equal(t, map[string]string{"app": "db"}, deployment.Spec.Selector.MatchLabels)
+
+	deployment, err = GenerateDeployment("worker", map[string]interface{}{"image": "worker"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, "true", deployment.Spec.Template.Metadata.Labels[NetworkLabel(DefaultNetwork)])
+}
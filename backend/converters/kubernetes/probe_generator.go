@@ -0,0 +1,353 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"devops-converter/converters/docker"
+)
+
+// Valeurs par défaut d'un healthcheck docker-compose, qui diffèrent de celles des probes Kubernetes
+// (période de 10s, timeout de 1s)
+const (
+	defaultHealthcheckIntervalSeconds = 30
+	defaultHealthcheckTimeoutSeconds  = 30
+	defaultHealthcheckRetries         = 3
+	// Intervalle par défaut des tests pendant start_period (start_interval de docker-compose)
+	defaultStartIntervalSeconds = 5
+)
+
+// probeSet probes générées à partir du healthcheck d'un service
+type probeSet struct {
+	liveness  *Probe
+	readiness *Probe
+	startup   *Probe
+}
+
+// Suffixes de test CMD-SHELL sans effet sur le code de sortie ("curl -f http://localhost || exit 1")
+var shellExitSuffix = regexp.MustCompile(`\s*\|\|\s*(exit(\s+1)?|false)\s*$`)
+
+// generateProbes génère les probes d'un healthcheck docker-compose :
+//   - curl/wget vers localhost deviennent un httpGet, nc -z vers localhost un tcpSocket,
+//     les autres tests une commande exec (CMD-SHELL exécuté par sh -c) ;
+//   - interval, timeout et retries sont appliqués aux probes liveness et readiness, avec les valeurs
+//     par défaut de docker-compose (30s, 30s et 3) lorsqu'ils ne sont pas renseignés ;
+//   - start_period devient une startupProbe qui laisse ce délai au conteneur pour démarrer ;
+//   - disable: true ou test: ["NONE"] désactive les probes.
+func generateProbes(healthcheck interface{}) (*probeSet, error) {
+	healthMap, ok := healthcheck.(map[string]interface{})
+	if !ok {
+		return &probeSet{}, nil
+	}
+	if disabled, _ := healthMap["disable"].(bool); disabled {
+		return &probeSet{}, nil
+	}
+
+	handler, ok := healthcheckHandler(healthMap["test"])
+	if !ok {
+		return &probeSet{}, nil
+	}
+
+	probe := Probe{
+		Handler:          handler,
+		PeriodSeconds:    defaultHealthcheckIntervalSeconds,
+		TimeoutSeconds:   defaultHealthcheckTimeoutSeconds,
+		FailureThreshold: defaultHealthcheckRetries,
+	}
+	for _, field := range []struct {
+		key    string
+		target *int32
+	}{
+		{"interval", &probe.PeriodSeconds},
+		{"timeout", &probe.TimeoutSeconds},
+	} {
+		value := stringValue(healthMap[field.key])
+		if value == "" {
+			continue
+		}
+		seconds, err := parseDurationToSeconds(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid healthcheck %s: %w", field.key, err)
+		}
+		*field.target = seconds
+	}
+	if retries := int32Value(healthMap["retries"]); retries > 0 {
+		probe.FailureThreshold = retries
+	}
+
+	liveness, readiness := probe, probe
+	probes := &probeSet{liveness: &liveness, readiness: &readiness}
+
+	if startPeriod := stringValue(healthMap["start_period"]); startPeriod != "" {
+		period, err := parseDurationToSeconds(startPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid healthcheck start_period: %w", err)
+		}
+		if period > 0 {
+			interval := int32(defaultStartIntervalSeconds)
+			if startInterval := stringValue(healthMap["start_interval"]); startInterval != "" {
+				if interval, err = parseDurationToSeconds(startInterval); err != nil {
+					return nil, fmt.Errorf("invalid healthcheck start_interval: %w", err)
+				}
+			}
+			if interval < 1 {
+				interval = 1
+			}
+			// Les échecs pendant start_period ne comptent pas : le conteneur a jusqu'à la fin du délai pour répondre
+			probes.startup = &Probe{
+				Handler:          handler,
+				TimeoutSeconds:   probe.TimeoutSeconds,
+				PeriodSeconds:    interval,
+				FailureThreshold: (period + interval - 1) / interval,
+			}
+		}
+	}
+
+	return probes, nil
+}
+
+// healthcheckHandler traduit le test d'un healthcheck en action de probe.
+// Une chaîne est un test CMD-SHELL ; une liste commence par CMD, CMD-SHELL ou NONE.
+func healthcheckHandler(test interface{}) (Handler, bool) {
+	var command []string
+	var shell string
+
+	if script, ok := test.(string); ok {
+		shell = script
+	} else {
+		args := normalizeStringSlice(test)
+		if len(args) == 0 {
+			return Handler{}, false
+		}
+		switch args[0] {
+		case "NONE":
+			return Handler{}, false
+		case "CMD":
+			command = args[1:]
+		case "CMD-SHELL":
+			shell = strings.Join(args[1:], " ")
+		default:
+			command = args
+		}
+	}
+
+	if strings.TrimSpace(shell) != "" {
+		// Une commande simple est analysée comme une commande exec
+		if words, err := docker.SplitSimpleCommand(shellExitSuffix.ReplaceAllString(shell, "")); err == nil && len(words) > 0 {
+			command = words
+		}
+	}
+
+	if len(command) > 0 {
+		if httpGet := httpGetFromCommand(command); httpGet != nil {
+			return Handler{HTTPGet: httpGet}, true
+		}
+		if tcpSocket := tcpSocketFromCommand(command); tcpSocket != nil {
+			return Handler{TCPSocket: tcpSocket}, true
+		}
+	}
+
+	if strings.TrimSpace(shell) != "" {
+		return Handler{Exec: &ExecAction{Command: []string{"sh", "-c", shell}}}, true
+	}
+	if len(command) == 0 {
+		return Handler{}, false
+	}
+	return Handler{Exec: &ExecAction{Command: command}}, true
+}
+
+// commandOptions options reconnues d'une commande de test : celles qui attendent une valeur
+// et celles qui n'en attendent pas ; toute autre option rend la commande intraduisible
+type commandOptions struct {
+	flags  string
+	values string
+	long   map[string]bool // option longue -> attend une valeur
+}
+
+var (
+	curlOptions = commandOptions{
+		flags:  "fsSLk",
+		values: "oHm",
+		long: map[string]bool{
+			"--fail": false, "--fail-with-body": false, "--silent": false, "--show-error": false,
+			"--location": false, "--insecure": false,
+			"--output": true, "--header": true, "--max-time": true, "--connect-timeout": true,
+		},
+	}
+	wgetOptions = commandOptions{
+		flags:  "qS",
+		values: "OTtU",
+		long: map[string]bool{
+			"--quiet": false, "--spider": false, "--server-response": false, "--no-check-certificate": false,
+			"--no-verbose": false, "-nv": false,
+			"--output-document": true, "--timeout": true, "--tries": true, "--header": true, "--user-agent": true,
+		},
+	}
+	netcatOptions = commandOptions{
+		flags:  "zvn",
+		values: "w",
+		long:   map[string]bool{},
+	}
+)
+
+// parsedCommand arguments positionnels et options d'une commande de test
+type parsedCommand struct {
+	args    []string
+	flags   map[string]bool
+	headers []string
+}
+
+// parseCommand sépare les options et les arguments d'une commande ; ok est faux si une option est inconnue
+func parseCommand(args []string, options commandOptions) (parsedCommand, bool) {
+	parsed := parsedCommand{flags: make(map[string]bool)}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		takesValue, long := options.long[name]
+
+		switch {
+		case !strings.HasPrefix(arg, "-") || arg == "-":
+			parsed.args = append(parsed.args, arg)
+
+		case long:
+			if takesValue && !hasValue {
+				if i+1 >= len(args) {
+					return parsed, false
+				}
+				i++
+				value = args[i]
+			}
+			parsed.flags[name] = true
+			if name == "--header" {
+				parsed.headers = append(parsed.headers, value)
+			}
+
+		case strings.HasPrefix(arg, "--"):
+			return parsed, false
+
+		default:
+			// Options courtes regroupées ("-fsS", "-qO-", "-w1")
+			letters := arg[1:]
+			for j := 0; j < len(letters); j++ {
+				letter := letters[j : j+1]
+				switch {
+				case strings.Contains(options.flags, letter):
+					parsed.flags["-"+letter] = true
+				case strings.Contains(options.values, letter):
+					value = letters[j+1:]
+					if value == "" {
+						if i+1 >= len(args) {
+							return parsed, false
+						}
+						i++
+						value = args[i]
+					}
+					parsed.flags["-"+letter] = true
+					if letter == "H" {
+						parsed.headers = append(parsed.headers, value)
+					}
+					j = len(letters)
+				default:
+					return parsed, false
+				}
+			}
+		}
+	}
+
+	return parsed, true
+}
+
+// isLocalHost indique si un hôte désigne le conteneur lui-même
+func isLocalHost(host string) bool {
+	switch strings.ToLower(host) {
+	case "localhost", "127.0.0.1", "0.0.0.0", "::1":
+		return true
+	}
+	return false
+}
+
+// httpGetFromCommand traduit "curl -f http://localhost:8080/health" ou "wget -q --spider ..."
+// en action httpGet ; nil si la commande n'est pas une simple requête vers localhost ou si curl
+// n'échoue pas sur les statuts d'erreur HTTP (--fail absent)
+func httpGetFromCommand(command []string) *HTTPGetAction {
+	var options commandOptions
+	switch path.Base(command[0]) {
+	case "curl":
+		options = curlOptions
+	case "wget":
+		options = wgetOptions
+	default:
+		return nil
+	}
+
+	parsed, ok := parseCommand(command[1:], options)
+	if !ok || len(parsed.args) != 1 {
+		return nil
+	}
+	// Sans --fail, curl réussit même sur une réponse 4xx ou 5xx, que httpGet considère comme un échec
+	if path.Base(command[0]) == "curl" && !parsed.flags["-f"] && !parsed.flags["--fail"] && !parsed.flags["--fail-with-body"] {
+		return nil
+	}
+
+	target := parsed.args[0]
+	if !strings.Contains(target, "://") {
+		target = "http://" + target
+	}
+	u, err := url.Parse(target)
+	if err != nil || !isLocalHost(u.Hostname()) || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	action := &HTTPGetAction{
+		Path: u.EscapedPath(),
+		Port: IntOrString(port),
+	}
+	if action.Path == "" {
+		action.Path = "/"
+	}
+	if u.RawQuery != "" {
+		action.Path += "?" + u.RawQuery
+	}
+	if u.Scheme == "https" {
+		action.Scheme = "HTTPS"
+	}
+	for _, header := range parsed.headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return nil
+		}
+		action.HTTPHeaders = append(action.HTTPHeaders, HTTPHeader{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+
+	return action
+}
+
+// tcpSocketFromCommand traduit "nc -z localhost 5432" en action tcpSocket
+func tcpSocketFromCommand(command []string) *TCPSocketAction {
+	if name := path.Base(command[0]); name != "nc" && name != "netcat" {
+		return nil
+	}
+
+	parsed, ok := parseCommand(command[1:], netcatOptions)
+	if !ok || !parsed.flags["-z"] || len(parsed.args) != 2 || !isLocalHost(parsed.args[0]) {
+		return nil
+	}
+	if port, err := strconv.Atoi(parsed.args[1]); err != nil || port < 1 || port > 65535 {
+		return nil
+	}
+
+	return &TCPSocketAction{Port: IntOrString(parsed.args[1])}
+}
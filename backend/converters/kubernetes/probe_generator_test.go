@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthcheckHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		test    interface{}
+		handler Handler
+	}{
+		{
+			name:    "curl against localhost",
+			test:    []interface{}{"CMD", "curl", "-fsS", "http://localhost:8080/health?full=1"},
+			handler: Handler{HTTPGet: &HTTPGetAction{Path: "/health?full=1", Port: "8080"}},
+		},
+		{
+			name: "curl in a shell with exit suffix and header",
+			test: []interface{}{"CMD-SHELL", "curl -f -H 'X-Probe: 1' https://127.0.0.1/ || exit 1"},
+			handler: Handler{HTTPGet: &HTTPGetAction{
+				Path:        "/",
+				Port:        "443",
+				Scheme:      "HTTPS",
+				HTTPHeaders: []HTTPHeader{{Name: "X-Probe", Value: "1"}},
+			}},
+		},
+		{
+			name:    "wget spider",
+			test:    "wget -q --spider localhost:3000/ready",
+			handler: Handler{HTTPGet: &HTTPGetAction{Path: "/ready", Port: "3000"}},
+		},
+		{
+			name:    "nc -z",
+			test:    []interface{}{"CMD", "nc", "-z", "-w", "2", "localhost", "5432"},
+			handler: Handler{TCPSocket: &TCPSocketAction{Port: "5432"}},
+		},
+		{
+			name:    "curl against another host stays exec",
+			test:    []interface{}{"CMD", "curl", "-f", "http://api:8080/"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"curl", "-f", "http://api:8080/"}}},
+		},
+		{
+			name:    "curl with fail-with-body",
+			test:    []interface{}{"CMD", "curl", "--fail-with-body", "-s", "http://localhost/health"},
+			handler: Handler{HTTPGet: &HTTPGetAction{Path: "/health", Port: "80"}},
+		},
+		{
+			name:    "curl without fail succeeds on HTTP errors and stays exec",
+			test:    []interface{}{"CMD", "curl", "-s", "http://localhost:8080/health"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"curl", "-s", "http://localhost:8080/health"}}},
+		},
+		{
+			name:    "curl HEAD request stays exec",
+			test:    []interface{}{"CMD", "curl", "-fI", "http://localhost:8080/health"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"curl", "-fI", "http://localhost:8080/health"}}},
+		},
+		{
+			name:    "unsupported curl option stays exec",
+			test:    []interface{}{"CMD-SHELL", "curl -X POST http://localhost/"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"sh", "-c", "curl -X POST http://localhost/"}}},
+		},
+		{
+			name:    "shell pipeline",
+			test:    []interface{}{"CMD-SHELL", "pg_isready -U $POSTGRES_USER"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"sh", "-c", "pg_isready -U $POSTGRES_USER"}}},
+		},
+		{
+			name:    "exec command",
+			test:    []interface{}{"CMD", "redis-cli", "ping"},
+			handler: Handler{Exec: &ExecAction{Command: []string{"redis-cli", "ping"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, ok := healthcheckHandler(tt.test)
+			require.True(t, ok)
+			assert.Equal(t, tt.handler, handler)
+		})
+	}
+
+	_, ok := healthcheckHandler([]interface{}{"NONE"})
+	assert.False(t, ok)
+}
+
+func TestGenerateProbes(t *testing.T) {
+	probes, err := generateProbes(map[string]interface{}{
+		"test":         []interface{}{"CMD", "redis-cli", "ping"},
+		"interval":     "1m30s",
+		"timeout":      "1.5s",
+		"retries":      5,
+		"start_period": "42s",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, probes.liveness)
+	assert.Equal(t, int32(90), probes.liveness.PeriodSeconds)
+	assert.Equal(t, int32(2), probes.liveness.TimeoutSeconds)
+	assert.Equal(t, int32(5), probes.liveness.FailureThreshold)
+	assert.Equal(t, probes.liveness, probes.readiness)
+	assert.NotSame(t, probes.liveness, probes.readiness)
+
+	require.NotNil(t, probes.startup)
+	assert.Equal(t, int32(defaultStartIntervalSeconds), probes.startup.PeriodSeconds)
+	assert.Equal(t, int32(9), probes.startup.FailureThreshold)
+	assert.Equal(t, probes.liveness.Handler, probes.startup.Handler)
+
+	// Sans start_period, pas de startupProbe ; interval, timeout et retries prennent les valeurs
+	// par défaut de docker-compose et non celles de Kubernetes
+	probes, err = generateProbes(map[string]interface{}{"test": []interface{}{"CMD", "redis-cli", "ping"}})
+	require.NoError(t, err)
+	require.NotNil(t, probes.liveness)
+	assert.Equal(t, int32(30), probes.liveness.PeriodSeconds)
+	assert.Equal(t, int32(30), probes.liveness.TimeoutSeconds)
+	assert.Equal(t, int32(3), probes.liveness.FailureThreshold)
+	assert.Equal(t, probes.liveness, probes.readiness)
+	assert.Nil(t, probes.startup)
+
+	// disable désactive toutes les probes
+	probes, err = generateProbes(map[string]interface{}{"test": "true", "disable": true})
+	require.NoError(t, err)
+	assert.Equal(t, &probeSet{}, probes)
+}
+
+func TestParseDurationToSeconds(t *testing.T) {
+	for duration, seconds := range map[string]int32{"30s": 30, "1m30s": 90, "2h": 7200, "500ms": 1, "15": 15} {
+		parsed, err := parseDurationToSeconds(duration)
+		require.NoError(t, err, duration)
+		assert.Equal(t, seconds, parsed, duration)
+	}
+
+	_, err := parseDurationToSeconds("soon")
+	assert.Error(t, err)
+}
@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// safeSysctls sysctls autorisés par défaut par le kubelet ; les autres doivent être
+// listés dans --allowed-unsafe-sysctls
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_syncookies":             true,
+}
+
+// namespacedSysctlPrefixes préfixes des sysctls isolés par namespace, seuls réglables par pod
+var namespacedSysctlPrefixes = []string{"kernel.shm", "kernel.msg", "kernel.sem", "fs.mqueue.", "net."}
+
+// SecurityIssue réglage de sécurité docker-compose que Kubernetes ne peut pas exprimer fidèlement
+type SecurityIssue struct {
+	// Path chemin du réglage dans le service, par exemple ["security_opt", "0"] ou ["sysctls", "vm.max_map_count"]
+	Path   []string
+	Reason string
+}
+
+// ResolveSecurity traduit les réglages de sécurité d'un service : user, privileged, read_only,
+// cap_add, cap_drop et security_opt vont dans le contexte du conteneur, group_add et sysctls
+// dans celui du pod. Les contextes sont nil lorsqu'aucun réglage ne s'y applique.
+func ResolveSecurity(serviceMap map[string]interface{}) (*SecurityContext, *PodSecurityContext, []SecurityIssue) {
+	var container SecurityContext
+	var pod PodSecurityContext
+	var issues []SecurityIssue
+
+	// user: "uid[:gid]" ; les noms ne sont connus que de l'image, hormis root
+	if user := stringValue(serviceMap["user"]); user != "" {
+		name, group, hasGroup := strings.Cut(user, ":")
+		if uid, ok := resolveID(name); ok {
+			container.RunAsUser = &uid
+		} else {
+			issues = append(issues, SecurityIssue{Path: []string{"user"}, Reason: fmt.Sprintf("user name %s cannot be resolved to a UID outside the image", name)})
+		}
+		if hasGroup {
+			if gid, ok := resolveID(group); ok {
+				container.RunAsGroup = &gid
+			} else {
+				issues = append(issues, SecurityIssue{Path: []string{"user"}, Reason: fmt.Sprintf("group name %s cannot be resolved to a GID outside the image", group)})
+			}
+		}
+	}
+
+	if privileged, ok := serviceMap["privileged"].(bool); ok && privileged {
+		container.Privileged = &privileged
+	}
+
+	if readOnly, ok := serviceMap["read_only"].(bool); ok && readOnly {
+		container.ReadOnlyRootFilesystem = &readOnly
+	}
+
+	// Capabilities, sans le préfixe CAP_ attendu par Docker
+	add := normalizeCapabilities(serviceMap["cap_add"])
+	drop := normalizeCapabilities(serviceMap["cap_drop"])
+	if len(add) > 0 || len(drop) > 0 {
+		container.Capabilities = &Capabilities{Add: add, Drop: drop}
+	}
+
+	noNewPrivileges := -1
+	for i, option := range normalizeStringSlice(serviceMap["security_opt"]) {
+		if reason := applySecurityOpt(&container, option); reason != "" {
+			issues = append(issues, SecurityIssue{Path: []string{"security_opt", strconv.Itoa(i)}, Reason: reason})
+		}
+		if container.AllowPrivilegeEscalation != nil && noNewPrivileges < 0 {
+			noNewPrivileges = i
+		}
+	}
+
+	// L'API refuse allowPrivilegeEscalation: false avec privileged ou CAP_SYS_ADMIN
+	if noNewPrivileges >= 0 && (container.Privileged != nil || containsString(add, "SYS_ADMIN") || containsString(add, "ALL")) {
+		container.AllowPrivilegeEscalation = nil
+		issues = append(issues, SecurityIssue{
+			Path:   []string{"security_opt", strconv.Itoa(noNewPrivileges)},
+			Reason: "no-new-privileges cannot be combined with privileged or the SYS_ADMIN capability in Kubernetes",
+		})
+	}
+
+	// group_add: groupes supplémentaires numériques
+	for i, group := range normalizeStringSlice(serviceMap["group_add"]) {
+		if gid, ok := resolveID(group); ok {
+			pod.SupplementalGroups = append(pod.SupplementalGroups, gid)
+		} else {
+			issues = append(issues, SecurityIssue{Path: []string{"group_add", strconv.Itoa(i)}, Reason: fmt.Sprintf("group name %s cannot be resolved to a GID outside the image", group)})
+		}
+	}
+
+	// sysctls, triés par nom ; seuls ceux isolés par namespace sont réglables sur un pod
+	if sysctls, ok := serviceMap["sysctls"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(sysctls) {
+			if !isNamespacedSysctl(name) {
+				issues = append(issues, SecurityIssue{Path: []string{"sysctls", name}, Reason: fmt.Sprintf("sysctl %s is not namespaced and can only be set on the node", name)})
+				continue
+			}
+			pod.Sysctls = append(pod.Sysctls, Sysctl{Name: name, Value: stringValue(sysctls[name])})
+			if !safeSysctls[name] {
+				issues = append(issues, SecurityIssue{Path: []string{"sysctls", name}, Reason: fmt.Sprintf("sysctl %s is unsafe and must be allowed with --allowed-unsafe-sysctls on the kubelet", name)})
+			}
+		}
+	}
+
+	// Les pods partagent par défaut le user namespace de l'hôte, ce qu'exprime "host"
+	if mode := stringValue(serviceMap["userns_mode"]); mode != "" && mode != "host" {
+		issues = append(issues, SecurityIssue{Path: []string{"userns_mode"}, Reason: fmt.Sprintf("user namespace mode %s has no Kubernetes equivalent", mode)})
+	}
+
+	var containerContext *SecurityContext
+	if !reflect.ValueOf(container).IsZero() {
+		containerContext = &container
+	}
+	var podContext *PodSecurityContext
+	if !reflect.ValueOf(pod).IsZero() {
+		podContext = &pod
+	}
+	return containerContext, podContext, issues
+}
+
+// applySecurityOpt applique une option security_opt ("clé=valeur" ou l'ancienne forme "clé:valeur")
+// et retourne la raison pour laquelle elle n'est pas traduite fidèlement
+func applySecurityOpt(container *SecurityContext, option string) string {
+	key, value := option, ""
+	if index := strings.IndexAny(option, "=:"); index >= 0 {
+		key, value = option[:index], option[index+1:]
+	}
+
+	switch key {
+	case "no-new-privileges":
+		switch value {
+		case "", "true":
+			allow := false
+			container.AllowPrivilegeEscalation = &allow
+		case "false":
+		default:
+			return fmt.Sprintf("invalid no-new-privileges value %s", value)
+		}
+	case "seccomp":
+		switch value {
+		case "unconfined":
+			container.SeccompProfile = &SeccompProfile{Type: "Unconfined"}
+		case "":
+			return "missing seccomp profile"
+		default:
+			container.SeccompProfile = &SeccompProfile{Type: "Localhost", LocalhostProfile: path.Base(value)}
+			return fmt.Sprintf("seccomp profile %s must be copied to the seccomp directory of every node", value)
+		}
+	case "apparmor":
+		switch value {
+		case "unconfined":
+			container.AppArmorProfile = &AppArmorProfile{Type: "Unconfined"}
+		case "docker-default":
+			container.AppArmorProfile = &AppArmorProfile{Type: "RuntimeDefault"}
+		case "":
+			return "missing AppArmor profile"
+		default:
+			container.AppArmorProfile = &AppArmorProfile{Type: "Localhost", LocalhostProfile: value}
+		}
+	case "label":
+		field, level, _ := strings.Cut(value, ":")
+		if container.SELinuxOptions == nil {
+			container.SELinuxOptions = &SELinuxOptions{}
+		}
+		switch field {
+		case "user":
+			container.SELinuxOptions.User = level
+		case "role":
+			container.SELinuxOptions.Role = level
+		case "type":
+			container.SELinuxOptions.Type = level
+		case "level":
+			container.SELinuxOptions.Level = level
+		default:
+			if *container.SELinuxOptions == (SELinuxOptions{}) {
+				container.SELinuxOptions = nil
+			}
+			if field == "disable" {
+				return "SELinux labeling cannot be disabled; use the label type spc_t instead"
+			}
+			return fmt.Sprintf("SELinux option %s is not supported", field)
+		}
+	default:
+		return fmt.Sprintf("security option %s is not supported", key)
+	}
+	return ""
+}
+
+// resolveID convertit un identifiant numérique d'utilisateur ou de groupe ; root vaut 0
+func resolveID(value string) (int64, bool) {
+	if value == "root" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	return id, err == nil && id >= 0
+}
+
+// normalizeCapabilities met les capabilities au format Kubernetes (NET_ADMIN plutôt que CAP_NET_ADMIN)
+func normalizeCapabilities(value interface{}) []string {
+	var capabilities []string
+	for _, capability := range normalizeStringSlice(value) {
+		capabilities = append(capabilities, strings.TrimPrefix(strings.ToUpper(capability), "CAP_"))
+	}
+	return capabilities
+}
+
+// isNamespacedSysctl indique si le sysctl est isolé par namespace et peut donc être réglé par pod
+func isNamespacedSysctl(name string) bool {
+	name = strings.ReplaceAll(name, "/", ".")
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateSecurityContext génère le contexte de sécurité du conteneur
+func generateSecurityContext(service map[string]interface{}) (*SecurityContext, error) {
+	securityContext, _, _ := ResolveSecurity(service)
+	return securityContext, nil
+}
+
+// generatePodSecurityContext génère le contexte de sécurité du pod (groupes supplémentaires et sysctls)
+func generatePodSecurityContext(service map[string]interface{}) *PodSecurityContext {
+	_, podSecurityContext, _ := ResolveSecurity(service)
+	return podSecurityContext
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecurity(t *testing.T) {
+	container, pod, issues := ResolveSecurity(map[string]interface{}{
+		"user":         "1000:1000",
+		"read_only":    true,
+		"cap_add":      []interface{}{"CAP_NET_ADMIN", "sys_time"},
+		"cap_drop":     []interface{}{"ALL"},
+		"security_opt": []interface{}{"no-new-privileges:true", "seccomp=/etc/docker/seccomp/strict.json", "apparmor=unconfined", "label=type:container_t", "systempaths=unconfined"},
+		"group_add":    []interface{}{"44", "video"},
+		"sysctls": map[string]interface{}{
+			"net.ipv4.ip_unprivileged_port_start": "0",
+			"net.core.somaxconn":                  "1024",
+			"vm.max_map_count":                    "262144",
+		},
+		"userns_mode": "host",
+	})
+
+	uid, gid, readOnly, allow := int64(1000), int64(1000), true, false
+	assert.Equal(t, &SecurityContext{
+		Capabilities:             &Capabilities{Add: []string{"NET_ADMIN", "SYS_TIME"}, Drop: []string{"ALL"}},
+		SELinuxOptions:           &SELinuxOptions{Type: "container_t"},
+		RunAsUser:                &uid,
+		RunAsGroup:               &gid,
+		ReadOnlyRootFilesystem:   &readOnly,
+		AllowPrivilegeEscalation: &allow,
+		SeccompProfile:           &SeccompProfile{Type: "Localhost", LocalhostProfile: "strict.json"},
+		AppArmorProfile:          &AppArmorProfile{Type: "Unconfined"},
+	}, container)
+	assert.Equal(t, &PodSecurityContext{
+		SupplementalGroups: []int64{44},
+		Sysctls: []Sysctl{
+			{Name: "net.core.somaxconn", Value: "1024"},
+			{Name: "net.ipv4.ip_unprivileged_port_start", Value: "0"},
+		},
+	}, pod)
+
+	var paths [][]string
+	for _, issue := range issues {
+		paths = append(paths, issue.Path)
+	}
+	assert.Equal(t, [][]string{
+		{"security_opt", "1"},
+		{"security_opt", "4"},
+		{"group_add", "1"},
+		{"sysctls", "net.core.somaxconn"},
+		{"sysctls", "vm.max_map_count"},
+	}, paths)
+}
+
+func TestResolveSecurityUnsupportedValues(t *testing.T) {
+	// Utilisateur nommé : seul le groupe numérique est conservé
+	container, pod, issues := ResolveSecurity(map[string]interface{}{"user": "postgres:70", "userns_mode": "private"})
+	gid := int64(70)
+	assert.Equal(t, &SecurityContext{RunAsGroup: &gid}, container)
+	assert.Nil(t, pod)
+	require.Len(t, issues, 2)
+	assert.Equal(t, []string{"user"}, issues[0].Path)
+	assert.Equal(t, []string{"userns_mode"}, issues[1].Path)
+
+	// root est le seul nom résolu
+	container, _, issues = ResolveSecurity(map[string]interface{}{"user": "root"})
+	root := int64(0)
+	assert.Equal(t, &SecurityContext{RunAsUser: &root}, container)
+	assert.Empty(t, issues)
+
+	// no-new-privileges est incompatible avec privileged
+	privileged := true
+	container, _, issues = ResolveSecurity(map[string]interface{}{"privileged": true, "security_opt": []interface{}{"no-new-privileges"}})
+	assert.Equal(t, &SecurityContext{Privileged: &privileged}, container)
+	require.Len(t, issues, 1)
+	assert.Equal(t, []string{"security_opt", "0"}, issues[0].Path)
+
+	container, pod, issues = ResolveSecurity(map[string]interface{}{})
+	assert.Nil(t, container)
+	assert.Nil(t, pod)
+	assert.Empty(t, issues)
+}
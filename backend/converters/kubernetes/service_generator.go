@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 )
@@ -40,7 +41,7 @@ func GenerateService(serviceName string, service interface{}, options GeneratorO
 	}
 
 	// Générer les ports du service
-	servicePorts, err := generateServicePorts(portSlice)
+	servicePorts, err := generateServicePorts(ports)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate service ports for %s: %w", serviceName, err)
 	}
@@ -50,22 +51,38 @@ func GenerateService(serviceName string, service interface{}, options GeneratorO
 	return kubernetesService, nil
 }
 
-// generateServicePorts génère les ports du service à partir des ports Docker Compose
-func generateServicePorts(ports []interface{}) ([]ServicePort, error) {
+// generateServicePorts génère les ports du service à partir des ports Docker Compose.
+// Chaque port cible le port nommé correspondant du conteneur.
+func generateServicePorts(ports interface{}) ([]ServicePort, error) {
+	specs, err := parsePortEntries(ports)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nom du port de conteneur associé à chaque couple port/protocole
+	containerPortNames := make(map[string]string)
+	for _, containerPort := range containerPortsFromSpecs(specs) {
+		containerPortNames[fmt.Sprintf("%d/%s", containerPort.ContainerPort, containerPort.Protocol)] = containerPort.Name
+	}
+
 	var servicePorts []ServicePort
+	seen := make(map[string]bool)
 
-	for i, port := range ports {
-		portStr, ok := port.(string)
-		if !ok {
+	for _, spec := range specs {
+		// Un Service ne peut pas exposer deux fois le même port avec le même protocole
+		key := fmt.Sprintf("%d/%s", spec.servicePort(), spec.Protocol)
+		if seen[key] {
 			continue
 		}
-
-		servicePort, err := parseServicePortMapping(portStr, i)
-		if err != nil {
-			return nil, fmt.Errorf("invalid port format %s: %w", portStr, err)
-		}
-
-		servicePorts = append(servicePorts, *servicePort)
+		seen[key] = true
+
+		servicePorts = append(servicePorts, ServicePort{
+			Name:        spec.Name,
+			Port:        spec.servicePort(),
+			TargetPort:  containerPortNames[containerPortKey(spec)],
+			Protocol:    spec.Protocol,
+			AppProtocol: spec.AppProtocol,
+		})
 	}
 
 	return servicePorts, nil
@@ -155,7 +172,7 @@ func GenerateConfigMap(serviceName string, service interface{}, options Generato
 		return nil, nil
 	}
 
-	envMap, err := normalizeEnvironmentForConfigMap(env)
+	envMap, _, err := splitEnvironment(env, options.SecretPatterns)
 	if err != nil {
 		return nil, fmt.Errorf("failed to normalize environment for %s: %w", serviceName, err)
 	}
@@ -168,7 +185,7 @@ func GenerateConfigMap(serviceName string, service interface{}, options Generato
 		APIVersion: "v1",
 		Kind:       "ConfigMap",
 		Metadata: Metadata{
-			Name:      fmt.Sprintf("%s-config", serviceName),
+			Name:      ConfigMapName(serviceName),
 			Namespace: options.Namespace,
 			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
 		},
@@ -178,26 +195,75 @@ func GenerateConfigMap(serviceName string, service interface{}, options Generato
 	return configMap, nil
 }
 
-// normalizeEnvironmentForConfigMap normalise les variables d'environnement pour ConfigMap
-func normalizeEnvironmentForConfigMap(env interface{}) (map[string]string, error) {
-	envMap := make(map[string]string)
+// ConfigMapName retourne le nom de la ConfigMap des variables non sensibles d'un service
+func ConfigMapName(serviceName string) string {
+	return ServiceObjectName(serviceName, "config")
+}
+
+// ServiceObjectName retourne le nom DNS d'un objet propre à un service (<service>-<suffixe>),
+// également utilisé pour nommer son fichier
+func ServiceObjectName(serviceName, suffix string) string {
+	return toDNSLabel(serviceName + "-" + suffix)
+}
+
+// GenerateSecret génère un Secret regroupant les variables d'environnement sensibles d'un service
+func GenerateSecret(serviceName string, service interface{}, options GeneratorOptions) (*Secret, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	env, ok := serviceMap["environment"]
+	if !ok {
+		return nil, nil
+	}
+
+	_, secretData, err := splitEnvironment(env, options.SecretPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize environment for %s: %w", serviceName, err)
+	}
+
+	if len(secretData) == 0 {
+		return nil, nil
+	}
+
+	secret := &Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: Metadata{
+			Name:      SecretName(serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Type:       "Opaque",
+		StringData: secretData,
+	}
+
+	return secret, nil
+}
+
+// SecretName retourne le nom du Secret des variables sensibles d'un service
+func SecretName(serviceName string) string {
+	return ServiceObjectName(serviceName, "secret")
+}
+
+// splitEnvironment normalise les variables d'environnement et sépare les variables
+// non sensibles (ConfigMap) des variables sensibles (Secret)
+func splitEnvironment(env interface{}, secretPatterns []string) (map[string]string, map[string]string, error) {
+	values := make(map[string]string)
 
 	switch e := env.(type) {
 	case map[string]interface{}:
 		for key, value := range e {
-			// Exclure les variables qui contiennent des secrets (mots de passe, clés, etc.)
-			if isSecretVariable(key) {
+			if value == nil {
+				values[key] = ""
 				continue
 			}
-			envMap[key] = fmt.Sprintf("%v", value)
+			values[key] = fmt.Sprintf("%v", value)
 		}
 	case map[string]string:
 		for key, value := range e {
-			// Exclure les variables qui contiennent des secrets (mots de passe, clés, etc.)
-			if isSecretVariable(key) {
-				continue
-			}
-			envMap[key] = value
+			values[key] = value
 		}
 	case []interface{}:
 		for _, item := range e {
@@ -205,204 +271,165 @@ func normalizeEnvironmentForConfigMap(env interface{}) (map[string]string, error
 			if !ok {
 				continue
 			}
-			parts := strings.SplitN(envStr, "=", 2)
-			key := parts[0]
-			
-			// Exclure les variables qui contiennent des secrets
-			if isSecretVariable(key) {
-				continue
-			}
-			
-			if len(parts) == 2 {
-				envMap[key] = parts[1]
-			} else {
-				envMap[key] = ""
-			}
+			key, value, _ := strings.Cut(envStr, "=")
+			values[key] = value
 		}
 	default:
-		return nil, fmt.Errorf("unsupported environment format: %T", env)
+		return nil, nil, fmt.Errorf("unsupported environment format: %T", env)
 	}
 
-	return envMap, nil
-}
-
-// isSecretVariable détermine si une variable d'environnement contient des données sensibles
-func isSecretVariable(key string) bool {
-	key = strings.ToLower(key)
-	secretPatterns := []string{
-		"password", "passwd", "pwd",
-		"secret", "key", "token",
-		"api_key", "apikey",
-		"private", "credential",
-		"auth", "oauth",
-	}
-
-	for _, pattern := range secretPatterns {
-		if strings.Contains(key, pattern) {
-			return true
+	config := make(map[string]string)
+	secrets := make(map[string]string)
+	for key, value := range values {
+		// Les variables qui contiennent des secrets (mots de passe, clés, etc.) vont dans le Secret
+		if isSecretVariable(key, secretPatterns) {
+			secrets[key] = value
+		} else {
+			config[key] = value
 		}
 	}
 
-	return false
+	return config, secrets, nil
 }
 
-// GeneratePersistentVolumeClaim génère un PVC pour les volumes nommés
-func GeneratePersistentVolumeClaim(serviceName string, service interface{}, options GeneratorOptions) ([]*PersistentVolumeClaim, error) {
-	serviceMap, ok := service.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid service format for %s", serviceName)
-	}
-
-	volumesConfig, ok := serviceMap["volumes"]
-	if !ok {
-		return nil, nil
-	}
-
-	volumeSlice, ok := volumesConfig.([]interface{})
-	if !ok {
-		return nil, nil
-	}
+// DefaultSecretPatterns motifs identifiant par défaut une variable d'environnement sensible
+var DefaultSecretPatterns = []string{
+	"password", "passwd", "pwd",
+	"secret", "key", "token",
+	"api_key", "apikey",
+	"private", "credential",
+	"auth", "oauth",
+}
 
-	var pvcs []*PersistentVolumeClaim
+// isSecretVariable détermine si une variable d'environnement contient des données sensibles.
+// Un motif est recherché dans le nom sans tenir compte de la casse ; les motifs contenant
+// des jokers (*, ?) doivent correspondre au nom entier.
+func isSecretVariable(key string, patterns []string) bool {
+	key = strings.ToLower(key)
 
-	for _, vol := range volumeSlice {
-		volStr, ok := vol.(string)
-		if !ok {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
 			continue
 		}
-
-		// Ne créer des PVCs que pour les volumes nommés (pas les bind mounts)
-		if !strings.HasPrefix(volStr, "/") && !strings.HasPrefix(volStr, ".") {
-			parts := strings.Split(volStr, ":")
-			if len(parts) >= 2 {
-				volumeName := parts[0]
-				
-				pvc := &PersistentVolumeClaim{
-					APIVersion: "v1",
-					Kind:       "PersistentVolumeClaim",
-					Metadata: Metadata{
-						Name:      fmt.Sprintf("%s-%s", serviceName, volumeName),
-						Namespace: options.Namespace,
-						Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
-					},
-					Spec: PersistentVolumeClaimSpec{
-						AccessModes: []string{"ReadWriteOnce"},
-						Resources: &ResourceRequirements{
-							Requests: map[string]string{
-								"storage": "1Gi", // Taille par défaut
-							},
-						},
-					},
-				}
-
-				pvcs = append(pvcs, pvc)
+		if strings.ContainsAny(pattern, "*?[") {
+			if matched, err := path.Match(pattern, key); err == nil && matched {
+				return true
 			}
+			continue
+		}
+		if strings.Contains(key, pattern) {
+			return true
 		}
 	}
 
-	return pvcs, nil
+	return false
 }
 
-// GenerateIngressForService génère un Ingress basique pour un service avec des ports HTTP
-func GenerateIngressForService(serviceName string, service interface{}, options GeneratorOptions, host string) (*KubernetesManifest, error) {
-	serviceMap, ok := service.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid service format for %s", serviceName)
-	}
-
-	// Vérifier s'il y a des ports HTTP
-	ports, ok := serviceMap["ports"]
-	if !ok {
-		return nil, nil
-	}
-
-	portSlice, ok := ports.([]interface{})
-	if !ok || len(portSlice) == 0 {
-		return nil, nil
-	}
-
-	// Chercher un port HTTP (80, 8080, 3000, etc.)
-	var httpPort int32
-	for _, port := range portSlice {
-		portStr, ok := port.(string)
-		if !ok {
-			continue
-		}
-
-		parts := strings.Split(portStr, ":")
-		var containerPort int
-		var err error
-
-		switch len(parts) {
-		case 1:
-			containerPort, err = strconv.Atoi(parts[0])
-		case 2:
-			containerPort, err = strconv.Atoi(parts[1])
-		case 3:
-			if strings.Contains(parts[2], "tcp") || strings.Contains(parts[2], "udp") {
-				containerPort, err = strconv.Atoi(parts[1])
-			} else {
-				containerPort, err = strconv.Atoi(parts[2])
-			}
-		}
-
-		if err == nil && isHTTPPort(containerPort) {
-			httpPort = int32(containerPort)
-			break
+// isHTTPPort détermine si un port est probablement un port HTTP
+func isHTTPPort(port int) bool {
+	commonHTTPPorts := []int{80, 8080, 3000, 3001, 4000, 5000, 8000, 8888, 9000}
+	for _, httpPort := range commonHTTPPorts {
+		if port == httpPort {
+			return true
 		}
 	}
+	return false
+}
 
-	if httpPort == 0 {
-		return nil, nil // Pas de port HTTP trouvé
-	}
+// Clés des références de fichiers d'un service (secrets et configs docker-compose)
+const (
+	fileReferenceSecrets = "secrets"
+	fileReferenceConfigs = "configs"
+)
 
-	if host == "" {
-		host = fmt.Sprintf("%s.local", serviceName)
+// GenerateFileSecret génère le Secret d'un secret docker-compose de premier niveau.
+// La clé du Secret est le nom du secret dans le fichier docker-compose.
+func GenerateFileSecret(key, name, data string, options GeneratorOptions) *Secret {
+	return &Secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: Metadata{
+			Name:      toDNSLabel(name),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, nil),
+		},
+		Type:       "Opaque",
+		StringData: map[string]string{key: data},
 	}
+}
 
-	ingress := &KubernetesManifest{
-		APIVersion: "networking.k8s.io/v1",
-		Kind:       "Ingress",
+// GenerateFileConfigMap génère la ConfigMap d'une config docker-compose de premier niveau.
+// La clé de la ConfigMap est le nom de la config dans le fichier docker-compose.
+func GenerateFileConfigMap(key, name, data string, options GeneratorOptions) *ConfigMap {
+	return &ConfigMap{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
 		Metadata: Metadata{
-			Name:      fmt.Sprintf("%s-ingress", serviceName),
+			Name:      toDNSLabel(name),
 			Namespace: options.Namespace,
-			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
-		},
-		Spec: map[string]interface{}{
-			"rules": []map[string]interface{}{
-				{
-					"host": host,
-					"http": map[string]interface{}{
-						"paths": []map[string]interface{}{
-							{
-								"path":     "/",
-								"pathType": "Prefix",
-								"backend": map[string]interface{}{
-									"service": map[string]interface{}{
-										"name": serviceName,
-										"port": map[string]interface{}{
-											"number": httpPort,
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			Labels:    mergeLabels(options.Labels, nil),
 		},
+		Data: map[string]string{key: data},
 	}
-
-	return ingress, nil
 }
 
-// isHTTPPort détermine si un port est probablement un port HTTP
-func isHTTPPort(port int) bool {
-	commonHTTPPorts := []int{80, 8080, 3000, 3001, 4000, 5000, 8000, 8888, 9000}
-	for _, httpPort := range commonHTTPPorts {
-		if port == httpPort {
-			return true
+// generateFileMounts monte les secrets et configs référencés par un service.
+// Chaque référence devient un volume exposant une seule clé, monté via subPath sur la cible
+// pour que plusieurs fichiers puissent partager un même répertoire.
+func generateFileMounts(service map[string]interface{}) ([]Volume, []VolumeMount) {
+	var volumes []Volume
+	var mounts []VolumeMount
+
+	for _, kind := range []string{fileReferenceSecrets, fileReferenceConfigs} {
+		references, _ := service[kind].([]interface{})
+		for i, item := range references {
+			reference, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			source := stringValue(reference["source"])
+			target := stringValue(reference["target"])
+			if source == "" || target == "" {
+				continue
+			}
+			resource := stringValue(reference["name"])
+			if resource == "" {
+				resource = source
+			}
+
+			var mode *int32
+			if value, ok := reference["mode"]; ok {
+				m := int32Value(value)
+				mode = &m
+			}
+
+			fileName := path.Base(target)
+			items := []KeyToPath{{Key: source, Path: fileName}}
+			volume := Volume{Name: toDNSLabel(fmt.Sprintf("%s-%s-%d", strings.TrimSuffix(kind, "s"), source, i))}
+			if kind == fileReferenceSecrets {
+				volume.Secret = &SecretVolumeSource{
+					SecretName:  toDNSLabel(resource),
+					Items:       items,
+					DefaultMode: mode,
+				}
+			} else {
+				volume.ConfigMap = &ConfigMapVolumeSource{
+					LocalObjectReference: LocalObjectReference{Name: toDNSLabel(resource)},
+					Items:                items,
+					DefaultMode:          mode,
+				}
+			}
+
+			volumes = append(volumes, volume)
+			mounts = append(mounts, VolumeMount{
+				Name:      volume.Name,
+				MountPath: target,
+				SubPath:   fileName,
+				ReadOnly:  true,
+			})
 		}
 	}
-	return false
+
+	return volumes, mounts
 }
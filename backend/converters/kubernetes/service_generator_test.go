@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "app",
+		"environment": map[string]string{
+			"DB_HOST":     "db",
+			"DB_PASSWORD": "s3cret",
+			"API_TOKEN":   "abc",
+		},
+	}
+	options := DefaultGeneratorOptions()
+
+	secret, err := GenerateSecret("api", service, options)
+	require.NoError(t, err)
+	require.NotNil(t, secret)
+	assert.Equal(t, "api-secret", secret.GetName())
+	assert.Equal(t, "Opaque", secret.Type)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "s3cret", "API_TOKEN": "abc"}, secret.StringData)
+
+	configMap, err := GenerateConfigMap("api", service, options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_HOST": "db"}, configMap.Data)
+
+	// Les valeurs sensibles ne sont jamais écrites en clair dans le conteneur
+	deployment, err := GenerateDeployment("api", service, options)
+	require.NoError(t, err)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	for _, env := range container.Env {
+		assert.NotEqual(t, "DB_PASSWORD", env.Name)
+		assert.NotEqual(t, "API_TOKEN", env.Name)
+	}
+	require.Len(t, container.EnvFrom, 2)
+	assert.Equal(t, "api-secret", container.EnvFrom[1].SecretRef.Name)
+}
+
+func TestServiceObjectNames(t *testing.T) {
+	service := map[string]interface{}{"image": "app", "environment": map[string]interface{}{"MODE": "primary", "DB_PASSWORD": "secret"}}
+
+	// Les noms de la ConfigMap et du Secret sont normalisés de la même façon
+	configMap, err := GenerateConfigMap("My_App", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "my-app-config", configMap.GetName())
+	secret, err := GenerateSecret("My_App", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "my-app-secret", secret.GetName())
+
+	deployment, err := GenerateDeployment("My_App", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	envFrom := deployment.Spec.Template.Spec.Containers[0].EnvFrom
+	require.Len(t, envFrom, 2)
+	assert.Equal(t, "my-app-config", envFrom[0].ConfigMapRef.Name)
+	assert.Equal(t, "my-app-secret", envFrom[1].SecretRef.Name)
+}
+
+func TestEnvironmentWiredThroughEnvFrom(t *testing.T) {
+	service := map[string]interface{}{
+		"image":       "app",
+		"environment": map[string]string{"DB_HOST": "db", "DB_PASSWORD": "s3cret"},
+	}
+
+	deployment, err := GenerateDeployment("api", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Empty(t, container.Env)
+	require.Len(t, container.EnvFrom, 2)
+	assert.Equal(t, "api-config", container.EnvFrom[0].ConfigMapRef.Name)
+	assert.Equal(t, "api-secret", container.EnvFrom[1].SecretRef.Name)
+
+	annotations := deployment.Spec.Template.Metadata.Annotations
+	checksum := annotations[ConfigChecksumAnnotation]
+	assert.Len(t, checksum, 64)
+	assert.NotEmpty(t, annotations[SecretChecksumAnnotation])
+
+	// Modifier une valeur de la ConfigMap change l'empreinte, et donc le template de pod
+	service["environment"] = map[string]string{"DB_HOST": "db-replica", "DB_PASSWORD": "s3cret"}
+	deployment, err = GenerateDeployment("api", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.NotEqual(t, checksum, deployment.Spec.Template.Metadata.Annotations[ConfigChecksumAnnotation])
+	assert.Equal(t, annotations[SecretChecksumAnnotation], deployment.Spec.Template.Metadata.Annotations[SecretChecksumAnnotation])
+}
+
+func TestIsSecretVariableWithPatterns(t *testing.T) {
+	assert.True(t, isSecretVariable("DB_PASSWORD", DefaultSecretPatterns))
+	assert.False(t, isSecretVariable("DB_HOST", DefaultSecretPatterns))
+
+	patterns := []string{"*_DSN", "vault"}
+	assert.True(t, isSecretVariable("SENTRY_DSN", patterns))
+	assert.True(t, isSecretVariable("VAULT_ADDR", patterns))
+	assert.False(t, isSecretVariable("DB_PASSWORD", patterns))
+	assert.False(t, isSecretVariable("DSN_HOST", patterns))
+
+	// Sans motif, aucune variable n'est considérée comme sensible
+	secret, err := GenerateSecret("api", map[string]interface{}{
+		"environment": map[string]string{"DB_PASSWORD": "s3cret"},
+	}, GeneratorOptions{SecretPatterns: []string{}})
+	require.NoError(t, err)
+	assert.Nil(t, secret)
+}
+
+func TestGenerateFileMounts(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "api",
+		"secrets": []interface{}{
+			map[string]interface{}{"source": "db_password", "target": "/run/secrets/db_password", "name": "db_password", "mode": 0400},
+		},
+		"configs": []interface{}{
+			map[string]interface{}{"source": "nginx", "target": "/etc/nginx/nginx.conf", "name": "nginx"},
+		},
+	}
+
+	deployment, err := GenerateDeployment("api", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+
+	volumes := deployment.Spec.Template.Spec.Volumes
+	require.Len(t, volumes, 2)
+	require.NotNil(t, volumes[0].Secret)
+	assert.Equal(t, "db-password", volumes[0].Secret.SecretName)
+	assert.Equal(t, []KeyToPath{{Key: "db_password", Path: "db_password"}}, volumes[0].Secret.Items)
+	assert.Equal(t, int32Ptr(0400), volumes[0].Secret.DefaultMode)
+	require.NotNil(t, volumes[1].ConfigMap)
+	assert.Equal(t, "nginx", volumes[1].ConfigMap.Name)
+	assert.Nil(t, volumes[1].ConfigMap.DefaultMode)
+
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	require.Len(t, mounts, 2)
+	assert.Equal(t, VolumeMount{Name: volumes[1].Name, MountPath: "/etc/nginx/nginx.conf", SubPath: "nginx.conf", ReadOnly: true}, mounts[1])
+}
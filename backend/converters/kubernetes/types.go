@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"strconv"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -83,9 +85,359 @@ type DeploymentSpec struct {
 	Strategy *DeploymentStrategy `yaml:"strategy,omitempty"`
 }
 
+// StatefulSet représente un StatefulSet Kubernetes
+type StatefulSet struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   Metadata        `yaml:"metadata"`
+	Spec       StatefulSetSpec `yaml:"spec"`
+}
+
+// ToYAML convertit le statefulset en YAML
+func (s *StatefulSet) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du statefulset
+func (s *StatefulSet) GetName() string {
+	return s.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (s *StatefulSet) GetKind() string {
+	return s.Kind
+}
+
+// StatefulSetSpec représente la spec d'un StatefulSet
+type StatefulSetSpec struct {
+	ServiceName          string                     `yaml:"serviceName"`
+	Replicas             int32                      `yaml:"replicas,omitempty"`
+	Selector             *LabelSelector             `yaml:"selector"`
+	Template             PodTemplateSpec            `yaml:"template"`
+	VolumeClaimTemplates []PersistentVolumeClaim    `yaml:"volumeClaimTemplates,omitempty"`
+	PodManagementPolicy  string                     `yaml:"podManagementPolicy,omitempty"`
+	UpdateStrategy       *StatefulSetUpdateStrategy `yaml:"updateStrategy,omitempty"`
+}
+
+// StatefulSetUpdateStrategy représente la stratégie de mise à jour d'un StatefulSet
+type StatefulSetUpdateStrategy struct {
+	Type          string                            `yaml:"type,omitempty"`
+	RollingUpdate *RollingUpdateStatefulSetStrategy `yaml:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateStatefulSetStrategy représente les paramètres de rolling update d'un StatefulSet
+type RollingUpdateStatefulSetStrategy struct {
+	Partition      *int32 `yaml:"partition,omitempty"`
+	MaxUnavailable string `yaml:"maxUnavailable,omitempty"`
+}
+
+// DaemonSet représente un DaemonSet Kubernetes
+type DaemonSet struct {
+	APIVersion string        `yaml:"apiVersion"`
+	Kind       string        `yaml:"kind"`
+	Metadata   Metadata      `yaml:"metadata"`
+	Spec       DaemonSetSpec `yaml:"spec"`
+}
+
+// ToYAML convertit le daemonset en YAML
+func (d *DaemonSet) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(d)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du daemonset
+func (d *DaemonSet) GetName() string {
+	return d.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (d *DaemonSet) GetKind() string {
+	return d.Kind
+}
+
+// DaemonSetSpec représente la spec d'un DaemonSet
+type DaemonSetSpec struct {
+	Selector        *LabelSelector           `yaml:"selector"`
+	Template        PodTemplateSpec          `yaml:"template"`
+	UpdateStrategy  *DaemonSetUpdateStrategy `yaml:"updateStrategy,omitempty"`
+	MinReadySeconds int32                    `yaml:"minReadySeconds,omitempty"`
+}
+
+// DaemonSetUpdateStrategy représente la stratégie de mise à jour d'un DaemonSet
+type DaemonSetUpdateStrategy struct {
+	Type          string                  `yaml:"type,omitempty"`
+	RollingUpdate *RollingUpdateDaemonSet `yaml:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateDaemonSet représente les paramètres de rolling update d'un DaemonSet
+type RollingUpdateDaemonSet struct {
+	MaxUnavailable *IntOrString `yaml:"maxUnavailable,omitempty"`
+	MaxSurge       *IntOrString `yaml:"maxSurge,omitempty"`
+}
+
+// IntOrString représente une valeur Kubernetes entière ou en pourcentage (ex: 1 ou "25%")
+type IntOrString string
+
+// MarshalYAML écrit la valeur comme un entier lorsqu'elle est numérique
+func (v IntOrString) MarshalYAML() (interface{}, error) {
+	if n, err := strconv.Atoi(string(v)); err == nil {
+		return n, nil
+	}
+	return string(v), nil
+}
+
+// NetworkPolicy représente une NetworkPolicy Kubernetes (networking.k8s.io/v1)
+type NetworkPolicy struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Spec       NetworkPolicySpec `yaml:"spec"`
+}
+
+// ToYAML convertit la network policy en YAML
+func (n *NetworkPolicy) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom de la network policy
+func (n *NetworkPolicy) GetName() string {
+	return n.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (n *NetworkPolicy) GetKind() string {
+	return n.Kind
+}
+
+// NetworkPolicySpec représente la spec d'une NetworkPolicy
+type NetworkPolicySpec struct {
+	PodSelector LabelSelector              `yaml:"podSelector"`
+	PolicyTypes []string                   `yaml:"policyTypes,omitempty"`
+	Ingress     []NetworkPolicyIngressRule `yaml:"ingress,omitempty"`
+	Egress      []NetworkPolicyEgressRule  `yaml:"egress,omitempty"`
+}
+
+// NetworkPolicyIngressRule représente une règle de trafic entrant
+type NetworkPolicyIngressRule struct {
+	From  []NetworkPolicyPeer `yaml:"from,omitempty"`
+	Ports []NetworkPolicyPort `yaml:"ports,omitempty"`
+}
+
+// NetworkPolicyEgressRule représente une règle de trafic sortant
+type NetworkPolicyEgressRule struct {
+	To    []NetworkPolicyPeer `yaml:"to,omitempty"`
+	Ports []NetworkPolicyPort `yaml:"ports,omitempty"`
+}
+
+// NetworkPolicyPeer représente les pods ou namespaces autorisés
+type NetworkPolicyPeer struct {
+	PodSelector       *LabelSelector `yaml:"podSelector,omitempty"`
+	NamespaceSelector *LabelSelector `yaml:"namespaceSelector,omitempty"`
+}
+
+// NetworkPolicyPort représente un port autorisé
+type NetworkPolicyPort struct {
+	Protocol string       `yaml:"protocol,omitempty"`
+	Port     *IntOrString `yaml:"port,omitempty"`
+}
+
+// HorizontalPodAutoscaler représente un HPA Kubernetes (autoscaling/v2)
+type HorizontalPodAutoscaler struct {
+	APIVersion string                      `yaml:"apiVersion"`
+	Kind       string                      `yaml:"kind"`
+	Metadata   Metadata                    `yaml:"metadata"`
+	Spec       HorizontalPodAutoscalerSpec `yaml:"spec"`
+}
+
+// ToYAML convertit le HPA en YAML
+func (h *HorizontalPodAutoscaler) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du HPA
+func (h *HorizontalPodAutoscaler) GetName() string {
+	return h.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (h *HorizontalPodAutoscaler) GetKind() string {
+	return h.Kind
+}
+
+// HorizontalPodAutoscalerSpec représente la spec d'un HPA
+type HorizontalPodAutoscalerSpec struct {
+	ScaleTargetRef CrossVersionObjectReference `yaml:"scaleTargetRef"`
+	MinReplicas    *int32                      `yaml:"minReplicas,omitempty"`
+	MaxReplicas    int32                       `yaml:"maxReplicas"`
+	Metrics        []MetricSpec                `yaml:"metrics,omitempty"`
+}
+
+// CrossVersionObjectReference représente le workload mis à l'échelle
+type CrossVersionObjectReference struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+}
+
+// MetricSpec représente une métrique suivie par un HPA
+type MetricSpec struct {
+	Type     string                `yaml:"type"`
+	Resource *ResourceMetricSource `yaml:"resource,omitempty"`
+}
+
+// ResourceMetricSource représente une métrique de ressource (cpu, memory)
+type ResourceMetricSource struct {
+	Name   string       `yaml:"name"`
+	Target MetricTarget `yaml:"target"`
+}
+
+// MetricTarget représente la valeur cible d'une métrique
+type MetricTarget struct {
+	Type               string `yaml:"type"`
+	AverageUtilization *int32 `yaml:"averageUtilization,omitempty"`
+}
+
+// PodDisruptionBudget représente un PodDisruptionBudget Kubernetes (policy/v1)
+type PodDisruptionBudget struct {
+	APIVersion string                  `yaml:"apiVersion"`
+	Kind       string                  `yaml:"kind"`
+	Metadata   Metadata                `yaml:"metadata"`
+	Spec       PodDisruptionBudgetSpec `yaml:"spec"`
+}
+
+// ToYAML convertit le PDB en YAML
+func (p *PodDisruptionBudget) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du PDB
+func (p *PodDisruptionBudget) GetName() string {
+	return p.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (p *PodDisruptionBudget) GetKind() string {
+	return p.Kind
+}
+
+// PodDisruptionBudgetSpec représente la spec d'un PDB
+type PodDisruptionBudgetSpec struct {
+	MinAvailable   *IntOrString   `yaml:"minAvailable,omitempty"`
+	MaxUnavailable *IntOrString   `yaml:"maxUnavailable,omitempty"`
+	Selector       *LabelSelector `yaml:"selector"`
+}
+
+// Job représente un Job Kubernetes (batch/v1)
+type Job struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       JobSpec  `yaml:"spec"`
+}
+
+// ToYAML convertit le job en YAML
+func (j *Job) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(j)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du job
+func (j *Job) GetName() string {
+	return j.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (j *Job) GetKind() string {
+	return j.Kind
+}
+
+// JobSpec représente la spec d'un Job
+type JobSpec struct {
+	BackoffLimit            *int32          `yaml:"backoffLimit,omitempty"`
+	Completions             *int32          `yaml:"completions,omitempty"`
+	Parallelism             *int32          `yaml:"parallelism,omitempty"`
+	ActiveDeadlineSeconds   *int64          `yaml:"activeDeadlineSeconds,omitempty"`
+	TTLSecondsAfterFinished *int32          `yaml:"ttlSecondsAfterFinished,omitempty"`
+	Template                PodTemplateSpec `yaml:"template"`
+}
+
+// CronJob représente un CronJob Kubernetes (batch/v1)
+type CronJob struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       CronJobSpec `yaml:"spec"`
+}
+
+// ToYAML convertit le cronjob en YAML
+func (c *CronJob) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du cronjob
+func (c *CronJob) GetName() string {
+	return c.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (c *CronJob) GetKind() string {
+	return c.Kind
+}
+
+// CronJobSpec représente la spec d'un CronJob
+type CronJobSpec struct {
+	Schedule                   string          `yaml:"schedule"`
+	TimeZone                   string          `yaml:"timeZone,omitempty"`
+	ConcurrencyPolicy          string          `yaml:"concurrencyPolicy,omitempty"`
+	StartingDeadlineSeconds    *int64          `yaml:"startingDeadlineSeconds,omitempty"`
+	SuccessfulJobsHistoryLimit *int32          `yaml:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32          `yaml:"failedJobsHistoryLimit,omitempty"`
+	JobTemplate                JobTemplateSpec `yaml:"jobTemplate"`
+}
+
+// JobTemplateSpec représente le template de Job d'un CronJob
+type JobTemplateSpec struct {
+	Metadata Metadata `yaml:"metadata,omitempty"`
+	Spec     JobSpec  `yaml:"spec"`
+}
+
 // LabelSelector représente un sélecteur de labels
 type LabelSelector struct {
-	MatchLabels map[string]string `yaml:"matchLabels,omitempty"`
+	MatchLabels      map[string]string          `yaml:"matchLabels,omitempty"`
+	MatchExpressions []LabelSelectorRequirement `yaml:"matchExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement représente une expression de sélection (In, NotIn, Exists, DoesNotExist)
+type LabelSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values,omitempty"`
 }
 
 // PodTemplateSpec représente le template d'un Pod
@@ -202,11 +554,348 @@ type ServiceSpec struct {
 
 // ServicePort représente un port de Service
 type ServicePort struct {
-	Name       string `yaml:"name,omitempty"`
-	Port       int32  `yaml:"port"`
-	TargetPort string `yaml:"targetPort,omitempty"` // peut être un nom ou un numéro
-	Protocol   string `yaml:"protocol,omitempty"`
-	NodePort   int32  `yaml:"nodePort,omitempty"`
+	Name        string `yaml:"name,omitempty"`
+	Port        int32  `yaml:"port"`
+	TargetPort  string `yaml:"targetPort,omitempty"` // peut être un nom ou un numéro
+	Protocol    string `yaml:"protocol,omitempty"`
+	AppProtocol string `yaml:"appProtocol,omitempty"`
+	NodePort    int32  `yaml:"nodePort,omitempty"`
+}
+
+// Secret représente un Secret Kubernetes
+type Secret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+}
+
+// ToYAML convertit le secret en YAML
+func (s *Secret) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du secret
+func (s *Secret) GetName() string {
+	return s.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (s *Secret) GetKind() string {
+	return s.Kind
+}
+
+// Ingress représente un Ingress Kubernetes (networking.k8s.io/v1)
+type Ingress struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       IngressSpec `yaml:"spec"`
+}
+
+// ToYAML convertit l'ingress en YAML
+func (i *Ingress) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(i)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom de l'ingress
+func (i *Ingress) GetName() string {
+	return i.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (i *Ingress) GetKind() string {
+	return i.Kind
+}
+
+// IngressSpec représente la spec d'un Ingress
+type IngressSpec struct {
+	IngressClassName string        `yaml:"ingressClassName,omitempty"`
+	TLS              []IngressTLS  `yaml:"tls,omitempty"`
+	Rules            []IngressRule `yaml:"rules"`
+}
+
+// IngressTLS représente la configuration TLS d'un Ingress
+type IngressTLS struct {
+	Hosts      []string `yaml:"hosts,omitempty"`
+	SecretName string   `yaml:"secretName,omitempty"`
+}
+
+// IngressRule représente une règle d'un Ingress
+type IngressRule struct {
+	Host string                `yaml:"host,omitempty"`
+	HTTP *HTTPIngressRuleValue `yaml:"http,omitempty"`
+}
+
+// HTTPIngressRuleValue représente les chemins HTTP d'une règle
+type HTTPIngressRuleValue struct {
+	Paths []HTTPIngressPath `yaml:"paths"`
+}
+
+// HTTPIngressPath représente un chemin HTTP routé vers un backend
+type HTTPIngressPath struct {
+	Path     string         `yaml:"path"`
+	PathType string         `yaml:"pathType"`
+	Backend  IngressBackend `yaml:"backend"`
+}
+
+// IngressBackend représente le backend d'un chemin
+type IngressBackend struct {
+	Service *IngressServiceBackend `yaml:"service,omitempty"`
+}
+
+// IngressServiceBackend représente un Service ciblé par un Ingress
+type IngressServiceBackend struct {
+	Name string             `yaml:"name"`
+	Port ServiceBackendPort `yaml:"port"`
+}
+
+// ServiceBackendPort représente le port du Service ciblé
+type ServiceBackendPort struct {
+	Name   string `yaml:"name,omitempty"`
+	Number int32  `yaml:"number,omitempty"`
+}
+
+// HTTPRoute représente une HTTPRoute Gateway API (gateway.networking.k8s.io/v1)
+type HTTPRoute struct {
+	APIVersion string        `yaml:"apiVersion"`
+	Kind       string        `yaml:"kind"`
+	Metadata   Metadata      `yaml:"metadata"`
+	Spec       HTTPRouteSpec `yaml:"spec"`
+}
+
+// ToYAML convertit la route en YAML
+func (r *HTTPRoute) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom de la route
+func (r *HTTPRoute) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (r *HTTPRoute) GetKind() string {
+	return r.Kind
+}
+
+// HTTPRouteSpec représente la spec d'une HTTPRoute
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `yaml:"parentRefs"`
+	Hostnames  []string          `yaml:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule   `yaml:"rules"`
+}
+
+// ParentReference représente la Gateway à laquelle une route est rattachée
+type ParentReference struct {
+	Name        string `yaml:"name"`
+	Namespace   string `yaml:"namespace,omitempty"`
+	SectionName string `yaml:"sectionName,omitempty"`
+}
+
+// HTTPRouteRule représente une règle de routage HTTP
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `yaml:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `yaml:"backendRefs"`
+}
+
+// HTTPRouteMatch représente les conditions d'une règle
+type HTTPRouteMatch struct {
+	Path *HTTPPathMatch `yaml:"path,omitempty"`
+}
+
+// HTTPPathMatch représente une condition sur le chemin (PathPrefix ou Exact)
+type HTTPPathMatch struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+// HTTPBackendRef représente un Service ciblé par une route
+type HTTPBackendRef struct {
+	Name string `yaml:"name"`
+	Port int32  `yaml:"port"`
+}
+
+// Gateway représente une Gateway Gateway API (gateway.networking.k8s.io/v1)
+type Gateway struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       GatewaySpec `yaml:"spec"`
+}
+
+// ToYAML convertit la gateway en YAML
+func (g *Gateway) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(g)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom de la gateway
+func (g *Gateway) GetName() string {
+	return g.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (g *Gateway) GetKind() string {
+	return g.Kind
+}
+
+// GatewaySpec représente la spec d'une Gateway
+type GatewaySpec struct {
+	GatewayClassName string     `yaml:"gatewayClassName"`
+	Listeners        []Listener `yaml:"listeners"`
+}
+
+// Listener représente un point d'écoute d'une Gateway
+type Listener struct {
+	Name          string            `yaml:"name"`
+	Hostname      string            `yaml:"hostname,omitempty"`
+	Port          int32             `yaml:"port"`
+	Protocol      string            `yaml:"protocol"`
+	TLS           *GatewayTLSConfig `yaml:"tls,omitempty"`
+	AllowedRoutes *AllowedRoutes    `yaml:"allowedRoutes,omitempty"`
+}
+
+// GatewayTLSConfig représente la terminaison TLS d'un listener
+type GatewayTLSConfig struct {
+	Mode            string                  `yaml:"mode,omitempty"`
+	CertificateRefs []SecretObjectReference `yaml:"certificateRefs,omitempty"`
+}
+
+// SecretObjectReference représente une référence vers un Secret de certificat
+type SecretObjectReference struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// AllowedRoutes représente les routes autorisées à se rattacher à un listener
+type AllowedRoutes struct {
+	Namespaces *RouteNamespaces `yaml:"namespaces,omitempty"`
+}
+
+// RouteNamespaces représente les namespaces autorisés (Same, All, Selector)
+type RouteNamespaces struct {
+	From     string         `yaml:"from,omitempty"`
+	Selector *LabelSelector `yaml:"selector,omitempty"`
+}
+
+// ServiceAccount représente un ServiceAccount Kubernetes
+type ServiceAccount struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+}
+
+// ToYAML convertit le service account en YAML
+func (s *ServiceAccount) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du service account
+func (s *ServiceAccount) GetName() string {
+	return s.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (s *ServiceAccount) GetKind() string {
+	return s.Kind
+}
+
+// Role représente un Role RBAC Kubernetes
+type Role struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Metadata   Metadata     `yaml:"metadata"`
+	Rules      []PolicyRule `yaml:"rules"`
+}
+
+// ToYAML convertit le role en YAML
+func (r *Role) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du role
+func (r *Role) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (r *Role) GetKind() string {
+	return r.Kind
+}
+
+// PolicyRule représente une règle d'autorisation d'un Role
+type PolicyRule struct {
+	APIGroups []string `yaml:"apiGroups"`
+	Resources []string `yaml:"resources"`
+	Verbs     []string `yaml:"verbs"`
+}
+
+// RoleBinding représente un RoleBinding RBAC Kubernetes
+type RoleBinding struct {
+	APIVersion string    `yaml:"apiVersion"`
+	Kind       string    `yaml:"kind"`
+	Metadata   Metadata  `yaml:"metadata"`
+	Subjects   []Subject `yaml:"subjects"`
+	RoleRef    RoleRef   `yaml:"roleRef"`
+}
+
+// ToYAML convertit le role binding en YAML
+func (b *RoleBinding) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du role binding
+func (b *RoleBinding) GetName() string {
+	return b.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (b *RoleBinding) GetKind() string {
+	return b.Kind
+}
+
+// Subject représente le compte auquel un RoleBinding accorde un Role
+type Subject struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// RoleRef représente le Role accordé par un RoleBinding
+type RoleRef struct {
+	APIGroup string `yaml:"apiGroup"`
+	Kind     string `yaml:"kind"`
+	Name     string `yaml:"name"`
 }
 
 // ConfigMap représente une ConfigMap Kubernetes
@@ -287,6 +976,25 @@ type PersistentVolumeClaim struct {
 	Spec       PersistentVolumeClaimSpec `yaml:"spec"`
 }
 
+// ToYAML convertit le PVC en YAML
+func (pvc *PersistentVolumeClaim) ToYAML() (string, error) {
+	yamlBytes, err := yaml.Marshal(pvc)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// GetName retourne le nom du PVC
+func (pvc *PersistentVolumeClaim) GetName() string {
+	return pvc.Metadata.Name
+}
+
+// GetKind retourne le type d'objet
+func (pvc *PersistentVolumeClaim) GetKind() string {
+	return pvc.Kind
+}
+
 // PersistentVolumeClaimSpec représente la spec d'un PersistentVolumeClaim
 type PersistentVolumeClaimSpec struct {
 	AccessModes      []string              `yaml:"accessModes"`
@@ -343,7 +1051,7 @@ type ExecAction struct {
 // HTTPGetAction représente une action HTTP GET
 type HTTPGetAction struct {
 	Path        string       `yaml:"path,omitempty"`
-	Port        string       `yaml:"port"`
+	Port        IntOrString  `yaml:"port"`
 	Host        string       `yaml:"host,omitempty"`
 	Scheme      string       `yaml:"scheme,omitempty"`
 	HTTPHeaders []HTTPHeader `yaml:"httpHeaders,omitempty"`
@@ -357,8 +1065,8 @@ type HTTPHeader struct {
 
 // TCPSocketAction représente une action TCP Socket
 type TCPSocketAction struct {
-	Port string `yaml:"port"`
-	Host string `yaml:"host,omitempty"`
+	Port IntOrString `yaml:"port"`
+	Host string      `yaml:"host,omitempty"`
 }
 
 // ResourceRequirements représente les exigences de ressources
@@ -493,6 +1201,7 @@ type SecurityContext struct {
 	AllowPrivilegeEscalation *bool                          `yaml:"allowPrivilegeEscalation,omitempty"`
 	ProcMount                string                         `yaml:"procMount,omitempty"`
 	SeccompProfile           *SeccompProfile                `yaml:"seccompProfile,omitempty"`
+	AppArmorProfile          *AppArmorProfile               `yaml:"appArmorProfile,omitempty"`
 }
 
 type PodSecurityContext struct {
@@ -532,6 +1241,11 @@ type SeccompProfile struct {
 	LocalhostProfile string `yaml:"localhostProfile,omitempty"`
 }
 
+type AppArmorProfile struct {
+	Type             string `yaml:"type"`
+	LocalhostProfile string `yaml:"localhostProfile,omitempty"`
+}
+
 type Sysctl struct {
 	Name  string `yaml:"name"`
 	Value string `yaml:"value"`
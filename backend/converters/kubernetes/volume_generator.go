@@ -0,0 +1,144 @@
+package kubernetes
+
+import "sort"
+
+// SharedVolume volume nommé monté par plusieurs pods, dont le PVC demande l'accès ReadWriteMany
+type SharedVolume struct {
+	Volume string
+	// Services services qui montent le volume depuis le PVC
+	Services []string
+}
+
+// VolumeClaimName retourne le nom du PVC d'un volume nommé. Comme dans docker-compose, le volume
+// appartient au projet : tous les services qui le montent utilisent le même PVC.
+func VolumeClaimName(volumeName string) string {
+	return toDNSLabel(volumeName)
+}
+
+// MarkSharedVolumes renseigne pour chaque service les volumes nommés également montés par un autre
+// service (clé shared_volumes). Un StatefulSet monte ces volumes depuis le PVC du projet plutôt que
+// depuis ses volumeClaimTemplates, qui créeraient un volume distinct par pod.
+func MarkSharedVolumes(services map[string]interface{}) {
+	owners := volumeOwners(services)
+	for _, service := range services {
+		serviceMap, ok := service.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var shared []interface{}
+		for _, volume := range namedVolumes(serviceMap) {
+			if len(owners[volume]) > 1 {
+				shared = append(shared, volume)
+			}
+		}
+		if len(shared) > 0 {
+			serviceMap["shared_volumes"] = shared
+		}
+	}
+}
+
+// GeneratePersistentVolumeClaims génère un PVC par volume nommé du projet, triés par nom. Les volumes
+// externes existent déjà et les volumes propres à un StatefulSet sont fournis par ses volumeClaimTemplates.
+// Un volume monté par plusieurs pods (plusieurs services, réplicas ou DaemonSet) demande l'accès
+// ReadWriteMany, que la classe de stockage doit supporter : il est retourné dans les volumes partagés.
+func GeneratePersistentVolumeClaims(services map[string]interface{}, volumes map[string]interface{}, options GeneratorOptions) ([]*PersistentVolumeClaim, []SharedVolume) {
+	owners := volumeOwners(services)
+	names := make([]string, 0, len(owners))
+	for name := range owners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var claims []*PersistentVolumeClaim
+	var shared []SharedVolume
+
+	for _, volume := range names {
+		if config, ok := volumes[volume].(map[string]interface{}); ok {
+			if external, _ := config["external"].(bool); external {
+				continue
+			}
+		}
+
+		var mounting []string
+		multiplePods := false
+		for _, serviceName := range owners[volume] {
+			serviceMap := services[serviceName].(map[string]interface{})
+			kind, _ := ResolveWorkloadKind(serviceName, serviceMap, options)
+			if kind == WorkloadStatefulSet && len(owners[volume]) == 1 {
+				continue
+			}
+			mounting = append(mounting, serviceName)
+			if kind == WorkloadDaemonSet || serviceReplicas(serviceMap, options) > 1 {
+				multiplePods = true
+			}
+		}
+		if len(mounting) == 0 {
+			continue
+		}
+
+		labels := options.Labels
+		if len(mounting) == 1 {
+			labels = mergeLabels(options.Labels, map[string]string{"app": mounting[0]})
+		}
+
+		accessMode := "ReadWriteOnce"
+		if len(mounting) > 1 || multiplePods {
+			accessMode = "ReadWriteMany"
+			shared = append(shared, SharedVolume{Volume: volume, Services: mounting})
+		}
+
+		claims = append(claims, &PersistentVolumeClaim{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Metadata: Metadata{
+				Name:      VolumeClaimName(volume),
+				Namespace: options.Namespace,
+				Labels:    labels,
+			},
+			Spec: PersistentVolumeClaimSpec{
+				AccessModes: []string{accessMode},
+				Resources: &ResourceRequirements{
+					Requests: map[string]string{
+						"storage": "1Gi", // Taille par défaut
+					},
+				},
+			},
+		})
+	}
+
+	return claims, shared
+}
+
+// volumeOwners associe chaque volume nommé aux services qui le montent, triés par nom
+func volumeOwners(services map[string]interface{}) map[string][]string {
+	owners := make(map[string][]string)
+	for name, service := range services {
+		serviceMap, ok := service.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, volume := range namedVolumes(serviceMap) {
+			owners[volume] = append(owners[volume], name)
+		}
+	}
+	for _, names := range owners {
+		sort.Strings(names)
+	}
+	return owners
+}
+
+// namedVolumes liste sans doublon les volumes nommés montés par un service
+func namedVolumes(serviceMap map[string]interface{}) []string {
+	var volumes []string
+	for _, spec := range parseVolumeEntries(serviceMap["volumes"]) {
+		if spec.Type == "volume" && spec.Source != "" && !containsString(volumes, spec.Source) {
+			volumes = append(volumes, spec.Source)
+		}
+	}
+	return volumes
+}
+
+// isSharedVolume indique si le volume nommé est également monté par un autre service
+func isSharedVolume(serviceMap map[string]interface{}, volume string) bool {
+	return containsString(normalizeStringSlice(serviceMap["shared_volumes"]), volume)
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePersistentVolumeClaimsSharedVolume(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.NamedVolumesAsStatefulSet = false
+
+	services := map[string]interface{}{
+		"app":    map[string]interface{}{"image": "app", "volumes": []interface{}{"shared:/data", "cache:/cache"}},
+		"worker": map[string]interface{}{"image": "worker", "volumes": []interface{}{"shared:/data:ro"}},
+	}
+	MarkSharedVolumes(services)
+
+	claims, shared := GeneratePersistentVolumeClaims(services, map[string]interface{}{
+		"shared": map[string]interface{}{"external": false},
+	}, options)
+
+	// Un seul PVC par volume, nommé d'après le volume
+	require.Len(t, claims, 2)
+	assert.Equal(t, "cache", claims[0].Metadata.Name)
+	assert.Equal(t, []string{"ReadWriteOnce"}, claims[0].Spec.AccessModes)
+	assert.Equal(t, "app", claims[0].Metadata.Labels["app"])
+	assert.Equal(t, "shared", claims[1].Metadata.Name)
+	assert.Equal(t, []string{"ReadWriteMany"}, claims[1].Spec.AccessModes)
+	assert.NotContains(t, claims[1].Metadata.Labels, "app")
+	assert.Equal(t, []SharedVolume{{Volume: "shared", Services: []string{"app", "worker"}}}, shared)
+
+	// Les deux services montent le même PVC
+	for _, name := range []string{"app", "worker"} {
+		deployment, err := GenerateDeployment(name, services[name], options)
+		require.NoError(t, err)
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, Volume{Name: "shared", PersistentVolumeClaim: &PVCVolumeSource{ClaimName: "shared"}})
+	}
+}
+
+func TestGeneratePersistentVolumeClaimsStatefulSet(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.StatefulServices = []string{"db"}
+
+	services := map[string]interface{}{
+		"db":     map[string]interface{}{"image": "postgres", "volumes": []interface{}{"pgdata:/var/lib/postgresql/data", "backups:/backups"}},
+		"backup": map[string]interface{}{"image": "backup", "volumes": []interface{}{"backups:/backups", "archive:/archive"}},
+	}
+	MarkSharedVolumes(services)
+
+	// Seul le volume propre archive fait de backup un StatefulSet, pas le volume partagé
+	kind, err := ResolveWorkloadKind("backup", services["backup"], options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadStatefulSet, kind)
+	kind, err = ResolveWorkloadKind("reader", map[string]interface{}{"image": "reader", "shared_volumes": []interface{}{"backups"}, "volumes": []interface{}{"backups:/backups"}}, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadDeployment, kind)
+
+	// pgdata vient du volumeClaimTemplate, backups du PVC partagé
+	statefulSet, err := GenerateStatefulSet("db", services["db"], options)
+	require.NoError(t, err)
+	require.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, "pgdata", statefulSet.Spec.VolumeClaimTemplates[0].Metadata.Name)
+	assert.Equal(t, []Volume{{Name: "backups", PersistentVolumeClaim: &PVCVolumeSource{ClaimName: "backups"}}}, statefulSet.Spec.Template.Spec.Volumes)
+
+	// archive est propre au StatefulSet backup, le volume externe n'est pas recréé
+	claims, shared := GeneratePersistentVolumeClaims(services, map[string]interface{}{
+		"backups": map[string]interface{}{"external": false},
+		"archive": map[string]interface{}{"external": false},
+	}, options)
+	require.Len(t, claims, 1)
+	assert.Equal(t, "backups", claims[0].Metadata.Name)
+	assert.Equal(t, []SharedVolume{{Volume: "backups", Services: []string{"backup", "db"}}}, shared)
+
+	claims, _ = GeneratePersistentVolumeClaims(services, map[string]interface{}{
+		"backups": map[string]interface{}{"external": true},
+	}, options)
+	assert.Empty(t, claims)
+}
+
+func TestToKubernetesQuantity(t *testing.T) {
+	for size, quantity := range map[string]string{"": "", "64m": "64Mi", "1g": "1Gi", "1.5GB": "1.5Gi", "100b": "100", "100B": "100", "1048576": "1048576", "512kb": "512Ki"} {
+		actual, err := toKubernetesQuantity(size)
+		require.NoError(t, err, size)
+		assert.Equal(t, quantity, actual, size)
+	}
+	for _, size := range []string{"b", "100x", "1e3", "-1m", "large"} {
+		_, err := toKubernetesQuantity(size)
+		assert.Error(t, err, size)
+	}
+
+	// Une taille de tmpfs invalide fait échouer la génération du volume
+	volumes, _, err := generateVolumes(map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"type": "tmpfs", "target": "/tmp", "size": "100b"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "100", volumes[0].EmptyDir.SizeLimit)
+	_, _, err = generateVolumes(map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"type": "tmpfs", "target": "/tmp", "size": "lots"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,306 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types de workload générés pour un service
+const (
+	WorkloadDeployment  = "deployment"
+	WorkloadStatefulSet = "statefulset"
+	WorkloadDaemonSet   = "daemonset"
+	WorkloadJob         = "job"
+	WorkloadCronJob     = "cronjob"
+)
+
+// WorkloadTypeLabel label docker-compose forçant le type de workload d'un service (compatible kompose)
+const WorkloadTypeLabel = "kompose.controller.type"
+
+// ResolveWorkloadKind détermine le type de workload à générer pour un service.
+// Le label du service est prioritaire, puis le label de planification d'un CronJob, l'option statefulServices,
+// deploy.mode global, les tâches ponctuelles (restart "no" ou "on-failure") et enfin la présence de volumes nommés propres au service.
+func ResolveWorkloadKind(serviceName string, service interface{}, options GeneratorOptions) (string, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	if labels, ok := serviceMap["labels"].(map[string]string); ok {
+		if kind, ok := labels[WorkloadTypeLabel]; ok {
+			switch kind = strings.ToLower(strings.TrimSpace(kind)); kind {
+			case WorkloadDeployment, WorkloadStatefulSet, WorkloadDaemonSet, WorkloadJob, WorkloadCronJob:
+				return kind, nil
+			default:
+				return "", fmt.Errorf("unsupported %s label value %q", WorkloadTypeLabel, kind)
+			}
+		}
+	}
+
+	if cronJobSchedule(serviceMap) != "" {
+		return WorkloadCronJob, nil
+	}
+
+	for _, name := range options.StatefulServices {
+		if name == serviceName {
+			return WorkloadStatefulSet, nil
+		}
+	}
+
+	if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok && stringValue(deploy["mode"]) == "global" {
+		return WorkloadDaemonSet, nil
+	}
+
+	if isJobService(serviceMap) {
+		return WorkloadJob, nil
+	}
+
+	if options.NamedVolumesAsStatefulSet && hasNamedVolumes(serviceMap) {
+		return WorkloadStatefulSet, nil
+	}
+
+	return WorkloadDeployment, nil
+}
+
+// GenerateWorkload génère le workload d'un service pour un type résolu par ResolveWorkloadKind
+func GenerateWorkload(kind, serviceName string, service interface{}, options GeneratorOptions) (KubernetesObject, error) {
+	switch kind {
+	case WorkloadStatefulSet:
+		return GenerateStatefulSet(serviceName, service, options)
+	case WorkloadDaemonSet:
+		return GenerateDaemonSet(serviceName, service, options)
+	case WorkloadJob:
+		return GenerateJob(serviceName, service, options)
+	case WorkloadCronJob:
+		return GenerateCronJob(serviceName, service, options)
+	default:
+		return GenerateDeployment(serviceName, service, options)
+	}
+}
+
+// GenerateStatefulSet génère un StatefulSet Kubernetes à partir d'un service Docker Compose.
+// Les volumes nommés deviennent des volumeClaimTemplates, un PVC par pod.
+func GenerateStatefulSet(serviceName string, service interface{}, options GeneratorOptions) (*StatefulSet, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	template, err := generatePodTemplate(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	claimTemplates := generateVolumeClaimTemplates(serviceName, serviceMap)
+
+	// Les volumes propres au service sont fournis par les volumeClaimTemplates, les volumes partagés par leur PVC
+	claimed := make(map[string]bool, len(claimTemplates))
+	for _, claim := range claimTemplates {
+		claimed[claim.Metadata.Name] = true
+	}
+	var volumes []Volume
+	for _, volume := range template.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && claimed[volume.Name] {
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	template.Spec.Volumes = volumes
+
+	statefulSet := &StatefulSet{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Metadata: Metadata{
+			Name:      serviceName,
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: StatefulSetSpec{
+			ServiceName: HeadlessServiceName(serviceName),
+			Replicas:    serviceReplicas(serviceMap, options),
+			Selector: &LabelSelector{
+				MatchLabels: map[string]string{"app": serviceName},
+			},
+			Template:             *template,
+			VolumeClaimTemplates: claimTemplates,
+		},
+	}
+
+	return statefulSet, nil
+}
+
+// GenerateDaemonSet génère un DaemonSet Kubernetes pour un service déployé en mode global (un pod par nœud).
+// deploy.update_config est traduit en paramètres de rolling update.
+func GenerateDaemonSet(serviceName string, service interface{}, options GeneratorOptions) (*DaemonSet, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	template, err := generatePodTemplate(serviceName, serviceMap, options)
+	if err != nil {
+		return nil, err
+	}
+
+	daemonSet := &DaemonSet{
+		APIVersion: "apps/v1",
+		Kind:       "DaemonSet",
+		Metadata: Metadata{
+			Name:      serviceName,
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: DaemonSetSpec{
+			Selector: &LabelSelector{
+				MatchLabels: map[string]string{"app": serviceName},
+			},
+			Template: *template,
+		},
+	}
+
+	if deploy, ok := serviceMap["deploy"].(map[string]interface{}); ok {
+		if updateConfig, ok := deploy["update_config"].(map[string]interface{}); ok {
+			strategy, minReadySeconds, err := daemonSetUpdateStrategy(updateConfig)
+			if err != nil {
+				return nil, fmt.Errorf("invalid update_config for %s: %w", serviceName, err)
+			}
+			daemonSet.Spec.UpdateStrategy = strategy
+			daemonSet.Spec.MinReadySeconds = minReadySeconds
+		}
+	}
+
+	return daemonSet, nil
+}
+
+// daemonSetUpdateStrategy traduit deploy.update_config en stratégie de rolling update d'un DaemonSet.
+// parallelism fixe le nombre de nœuds mis à jour simultanément (0 : tous), order choisit entre
+// maxUnavailable (stop-first) et maxSurge (start-first), delay devient minReadySeconds.
+func daemonSetUpdateStrategy(updateConfig map[string]interface{}) (*DaemonSetUpdateStrategy, int32, error) {
+	batch := IntOrString("100%")
+	if parallelism := int32Value(updateConfig["parallelism"]); parallelism > 0 {
+		batch = IntOrString(strconv.Itoa(int(parallelism)))
+	}
+
+	rollingUpdate := &RollingUpdateDaemonSet{}
+	switch order := stringValue(updateConfig["order"]); order {
+	case "", "stop-first":
+		rollingUpdate.MaxUnavailable = &batch
+	case "start-first":
+		zero := IntOrString("0")
+		rollingUpdate.MaxSurge = &batch
+		rollingUpdate.MaxUnavailable = &zero
+	default:
+		return nil, 0, fmt.Errorf("unsupported update order %q", order)
+	}
+
+	var minReadySeconds int32
+	if delay := stringValue(updateConfig["delay"]); delay != "" {
+		duration, err := time.ParseDuration(delay)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid delay %q: %w", delay, err)
+		}
+		minReadySeconds = int32(duration.Seconds())
+	}
+
+	return &DaemonSetUpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: rollingUpdate,
+	}, minReadySeconds, nil
+}
+
+// GenerateHeadlessService génère le Service headless qui gouverne l'identité réseau des pods d'un StatefulSet
+func GenerateHeadlessService(serviceName string, service interface{}, options GeneratorOptions) (*Service, error) {
+	serviceMap, ok := service.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid service format for %s", serviceName)
+	}
+
+	headlessService := &Service{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata: Metadata{
+			Name:      HeadlessServiceName(serviceName),
+			Namespace: options.Namespace,
+			Labels:    mergeLabels(options.Labels, map[string]string{"app": serviceName}),
+		},
+		Spec: ServiceSpec{
+			ClusterIP: "None",
+			Selector:  map[string]string{"app": serviceName},
+		},
+	}
+
+	if ports, ok := serviceMap["ports"]; ok {
+		servicePorts, err := generateServicePorts(ports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate service ports for %s: %w", serviceName, err)
+		}
+		headlessService.Spec.Ports = servicePorts
+	}
+
+	return headlessService, nil
+}
+
+// HeadlessServiceName retourne le nom du Service headless d'un StatefulSet
+func HeadlessServiceName(serviceName string) string {
+	return toDNSLabel(serviceName + "-headless")
+}
+
+// generateVolumeClaimTemplates génère un volumeClaimTemplate par volume nommé propre au service ;
+// les volumes partagés avec d'autres services restent montés depuis le PVC du projet
+func generateVolumeClaimTemplates(serviceName string, serviceMap map[string]interface{}) []PersistentVolumeClaim {
+	volumesConfig, ok := serviceMap["volumes"]
+	if !ok {
+		return nil
+	}
+
+	var claims []PersistentVolumeClaim
+	created := make(map[string]bool)
+
+	for _, spec := range parseVolumeEntries(volumesConfig) {
+		if spec.Type != "volume" || spec.Source == "" || isSharedVolume(serviceMap, spec.Source) {
+			continue
+		}
+
+		// Le nom du template correspond au nom du volume monté par le conteneur
+		name := toDNSLabel(spec.Source)
+		if created[name] {
+			continue
+		}
+		created[name] = true
+
+		claims = append(claims, PersistentVolumeClaim{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Metadata: Metadata{
+				Name:   name,
+				Labels: map[string]string{"app": serviceName},
+			},
+			Spec: PersistentVolumeClaimSpec{
+				AccessModes: []string{"ReadWriteOnce"},
+				Resources: &ResourceRequirements{
+					Requests: map[string]string{
+						"storage": "1Gi", // Taille par défaut
+					},
+				},
+			},
+		})
+	}
+
+	return claims
+}
+
+// hasNamedVolumes indique si un service monte au moins un volume nommé qui lui est propre
+func hasNamedVolumes(serviceMap map[string]interface{}) bool {
+	volumesConfig, ok := serviceMap["volumes"]
+	if !ok {
+		return false
+	}
+	for _, spec := range parseVolumeEntries(volumesConfig) {
+		if spec.Type == "volume" && spec.Source != "" && !isSharedVolume(serviceMap, spec.Source) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveWorkloadKind(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	database := map[string]interface{}{
+		"image":   "postgres:16",
+		"volumes": []interface{}{map[string]interface{}{"type": "volume", "source": "pgdata", "target": "/var/lib/postgresql/data"}},
+	}
+	cache := map[string]interface{}{"image": "redis:7"}
+
+	kind, err := ResolveWorkloadKind("db", database, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadStatefulSet, kind)
+
+	kind, err = ResolveWorkloadKind("cache", cache, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadDeployment, kind)
+
+	options.StatefulServices = []string{"cache"}
+	kind, err = ResolveWorkloadKind("cache", cache, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadStatefulSet, kind)
+
+	options.NamedVolumesAsStatefulSet = false
+	kind, err = ResolveWorkloadKind("db", database, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadDeployment, kind)
+
+	database["labels"] = map[string]string{WorkloadTypeLabel: "StatefulSet"}
+	kind, err = ResolveWorkloadKind("db", database, options)
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadStatefulSet, kind)
+
+	database["labels"] = map[string]string{WorkloadTypeLabel: "replicaset"}
+	_, err = ResolveWorkloadKind("db", database, options)
+	assert.Error(t, err)
+}
+
+func TestGenerateStatefulSet(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "postgres:16",
+		"ports": []interface{}{map[string]interface{}{"target": 5432, "protocol": "tcp"}},
+		"volumes": []interface{}{
+			map[string]interface{}{"type": "volume", "source": "pgdata", "target": "/var/lib/postgresql/data"},
+			map[string]interface{}{"type": "bind", "source": "/etc/postgres", "target": "/etc/postgresql", "read_only": true},
+		},
+	}
+
+	statefulSet, err := GenerateStatefulSet("db", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+
+	assert.Equal(t, "StatefulSet", statefulSet.GetKind())
+	assert.Equal(t, "db-headless", statefulSet.Spec.ServiceName)
+	require.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, "pgdata", statefulSet.Spec.VolumeClaimTemplates[0].Metadata.Name)
+
+	// Le volume nommé est fourni par le template, seul le bind mount reste déclaré dans le pod
+	require.Len(t, statefulSet.Spec.Template.Spec.Volumes, 1)
+	assert.NotNil(t, statefulSet.Spec.Template.Spec.Volumes[0].HostPath)
+	mounts := statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts
+	require.Len(t, mounts, 2)
+	assert.Equal(t, "pgdata", mounts[0].Name)
+
+	headless, err := GenerateHeadlessService("db", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "db-headless", headless.GetName())
+	assert.Equal(t, "None", headless.Spec.ClusterIP)
+	require.Len(t, headless.Spec.Ports, 1)
+	assert.Equal(t, int32(5432), headless.Spec.Ports[0].Port)
+}
+
+func TestGenerateDaemonSet(t *testing.T) {
+	service := map[string]interface{}{
+		"image": "prom/node-exporter",
+		"deploy": map[string]interface{}{
+			"mode": "global",
+			"update_config": map[string]interface{}{
+				"parallelism": 2,
+				"delay":       "1m30s",
+				"order":       "start-first",
+			},
+		},
+	}
+
+	kind, err := ResolveWorkloadKind("exporter", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, WorkloadDaemonSet, kind)
+
+	workload, err := GenerateWorkload(kind, "exporter", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	daemonSet, ok := workload.(*DaemonSet)
+	require.True(t, ok)
+
+	assert.Equal(t, int32(90), daemonSet.Spec.MinReadySeconds)
+	require.NotNil(t, daemonSet.Spec.UpdateStrategy)
+	assert.Equal(t, "RollingUpdate", daemonSet.Spec.UpdateStrategy.Type)
+
+	manifest, err := daemonSet.ToYAML()
+	require.NoError(t, err)
+	assert.Contains(t, manifest, "maxSurge: 2\n")
+	assert.Contains(t, manifest, "maxUnavailable: 0\n")
+	assert.NotContains(t, manifest, "replicas")
+
+	service["deploy"].(map[string]interface{})["update_config"] = map[string]interface{}{}
+	daemonSet, err = GenerateDaemonSet("exporter", service, DefaultGeneratorOptions())
+	require.NoError(t, err)
+	assert.Equal(t, IntOrString("100%"), *daemonSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable)
+}
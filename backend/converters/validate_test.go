@@ -0,0 +1,49 @@
+package converters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequest(t *testing.T) {
+	converter := NewDockerComposeToKubernetesConverter().(*DockerComposeToKubernetesConverter)
+	content := `include:
+  - db.yaml
+services:
+  app:
+    image: app:${TAG:?TAG is required}
+    deploy:
+      replicas: ${REPLICAS}
+`
+	db := SourceFile{Name: "db.yaml", Content: "services:\n  db:\n    image: postgres\n"}
+
+	// Sans .env ni bundle, la validation échoue comme la conversion
+	err := converter.ValidateRequest(context.Background(), ConversionRequest{Type: "docker-compose", Content: content})
+	var validationErrors ValidationErrors
+	require.True(t, errors.As(err, &validationErrors))
+	assert.NotEmpty(t, validationErrors)
+	assert.Error(t, converter.Validate(context.Background(), content, "docker-compose"))
+
+	// Avec les mêmes entrées que Convert, la requête est valide
+	req := ConversionRequest{
+		Type:    "docker-compose",
+		Content: content,
+		Options: map[string]interface{}{"envFile": "TAG=1.2.0\nREPLICAS=3\n"},
+		Bundle:  []SourceFile{db},
+	}
+	assert.NoError(t, converter.ValidateRequest(context.Background(), req))
+
+	result, err := converter.Convert(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	// Un .env invalide est signalé comme en conversion
+	req.Options = map[string]interface{}{"envFile": "TAG=\"unterminated\n"}
+	err = converter.ValidateRequest(context.Background(), req)
+	require.True(t, errors.As(err, &validationErrors))
+	assert.Equal(t, "ENV_FILE_ERROR", validationErrors[0].Code)
+}
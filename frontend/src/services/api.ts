@@ -59,7 +59,11 @@ class ApiService {
     // Restructurer la requête pour correspondre à l'API backend
     const apiRequest = {
       type: request.type,
-      content: request.content  // Le backend attend "content" aussi pour la validation
+      content: request.content,  // Le backend attend "content" aussi pour la validation
+      options: request.options,  // .env, overrides et bundle : mêmes entrées que la conversion
+      filename: request.filename,
+      documents: request.documents,
+      bundle: request.bundle
     }
     try {
       const response: AxiosResponse<ValidationResponse> = await this.api.post('/api/v1/convert/validate', apiRequest)
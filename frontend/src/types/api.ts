@@ -3,6 +3,14 @@ export interface ConversionRequest {
   type: string
   content: string
   options?: ConversionOptions
+  filename?: string
+  documents?: SourceFile[]
+  bundle?: SourceFile[]
+}
+
+export interface SourceFile {
+  name: string
+  content: string
 }
 
 export interface ConversionOptions {
@@ -12,9 +20,56 @@ export interface ConversionOptions {
   projectName?: string
   outputType?: 'all-in-one' | 'separate'
   allInOne?: boolean
+  profiles?: string[]
+  services?: string[]
+  statefulServices?: string[]
+  namedVolumesAsStatefulSet?: boolean
+  secretPatterns?: string[]
+  ingress?: boolean
+  ingressDomain?: string
+  ingressHosts?: Record<string, string>
+  ingressClass?: string
+  ingressTLSSecret?: string
+  ingressPathPrefix?: string
+  certManagerIssuer?: string
+  certManagerClusterIssuer?: string
+  exposureMode?: 'ingress' | 'gateway-api'
+  gatewayName?: string
+  gatewayNamespace?: string
+  gatewayClass?: string
+  autoscaling?: boolean
+  hpaMinReplicas?: number
+  hpaMaxReplicas?: number
+  hpaTargetCPU?: number
+  hpaTargetMemory?: number
+  autoscalingServices?: Record<string, AutoscalingTargets>
+  podDisruptionBudget?: boolean
+  pdbMinAvailable?: number | string
+  pdbMaxUnavailable?: number | string
+  podDisruptionBudgetServices?: Record<string, DisruptionBudget>
+  networkPolicies?: boolean
+  networkPolicyIngressNamespace?: string
+  networkPolicyIngressPodLabels?: Record<string, string>
+  dependencyWaitImage?: string
+  dependencyJobWaitImage?: string
+  dependencyWaitTimeout?: number | string
+  hardening?: 'none' | 'baseline' | 'restricted'
+  hardeningScratchPaths?: string[]
   [key: string]: any
 }
 
+export interface AutoscalingTargets {
+  minReplicas?: number
+  maxReplicas?: number
+  targetCPU?: number
+  targetMemory?: number
+}
+
+export interface DisruptionBudget {
+  minAvailable?: number | string
+  maxUnavailable?: number | string
+}
+
 export interface ConversionResponse {
   success: boolean
   files: GeneratedFile[]
@@ -31,31 +86,73 @@ export interface GeneratedFile {
   path: string
 }
 
-export interface ApiError {
+// Position d'une erreur ou d'un avertissement dans les fichiers source
+export interface SourceLocation {
+  file?: string
+  line?: number
+  column?: number
+  field?: string // Pointeur JSON, ex: /services/web/ports/0
+}
+
+export interface ApiError extends SourceLocation {
   code: string
   message: string
+  suggestion?: string
 }
 
-export interface ApiWarning {
+export interface ApiWarning extends SourceLocation {
   code: string
   message: string
+  suggestion?: string
 }
 
 export interface ConversionMetadata {
   docker_version?: string
   services_converted: number
   volumes_converted: number
+  coverage?: CoverageReport
+}
+
+export type CoverageStatus = 'mapped' | 'partial' | 'ignored'
+
+// Couverture d'une clé docker-compose par la conversion
+export interface FieldCoverage {
+  key: string
+  field: string
+  status: CoverageStatus
+  reason?: string
+  file?: string
+  line?: number
+}
+
+export interface ServiceCoverage {
+  service: string
+  fields: FieldCoverage[]
+  mapped: number
+  partial: number
+  ignored: number
+}
+
+export interface CoverageReport {
+  services: ServiceCoverage[]
+  mapped: number
+  partial: number
+  ignored: number
 }
 
 export interface ValidationRequest {
   type: string
   content: string
+  options?: ConversionOptions
+  filename?: string
+  documents?: SourceFile[]
+  bundle?: SourceFile[]
 }
 
 export interface ValidationResponse {
   valid: boolean
   message: string
-  errors?: string[]
+  errors?: ApiError[]
 }
 
 export interface ConverterInfo {